@@ -29,7 +29,7 @@ func main() {
 	m.SlashCommand("bongo", bongoHandler)
 
 	g := corde.GuildOpt(corde.SnowflakeFromString(os.Getenv("DISCORD_GUILD_ID")))
-	if err := m.RegisterCommand(command, g); err != nil {
+	if _, err := m.RegisterCommand(context.Background(), command, g); err != nil {
 		log.Fatalln("error registering command: ", err)
 	}
 
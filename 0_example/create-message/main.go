@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
@@ -26,7 +27,7 @@ func main() {
 		Description("corde is awesome :knot:").
 		Message()
 
-	msg, err := m.CreateMessage(chID, message)
+	msg, err := m.CreateMessage(context.Background(), chID, message)
 	if err != nil {
 		log.Fatalln("error creating message: ", err)
 	}
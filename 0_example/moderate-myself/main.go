@@ -39,11 +39,11 @@ func main() {
 		m.Route("list", func(m *corde.Mux) {
 			m.SlashCommand("", list(m, g))
 			m.ButtonComponent("next", btnNext(m, g, mu, &selectedID))
-			m.ButtonComponent("remove", btnRemove(m, g, mu, &selectedID))
+			m.ButtonComponentE("remove", btnRemove(m, g, mu, &selectedID))
 		})
 	})
 
-	if err := m.RegisterCommand(command, g); err != nil {
+	if _, err := m.RegisterCommand(context.Background(), command, g); err != nil {
 		log.Fatalln("error registering command: ", err)
 	}
 
@@ -83,7 +83,7 @@ func btnNext(m *corde.Mux, g func(*corde.CommandsOpt), mu *sync.Mutex, selectedI
 	return func(ctx context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) {
 		mu.Lock()
 		defer mu.Unlock()
-		commands, err := m.GetCommands(g)
+		commands, err := m.GetCommands(ctx, g)
 		if err != nil {
 			w.Update(corde.NewResp().Contentf("Error getting commands: %s", err.Error()).Ephemeral())
 			return
@@ -103,23 +103,27 @@ func btnNext(m *corde.Mux, g func(*corde.CommandsOpt), mu *sync.Mutex, selectedI
 	}
 }
 
-func btnRemove(m *corde.Mux, g func(*corde.CommandsOpt), mu *sync.Mutex, selectedID *int) func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.ButtonInteractionData]) {
-	return func(ctx context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) {
+func btnRemove(m *corde.Mux, g func(*corde.CommandsOpt), mu *sync.Mutex, selectedID *int) func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.ButtonInteractionData]) error {
+	return func(ctx context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) error {
 		mu.Lock()
 		defer mu.Unlock()
-		commands, err := m.GetCommands(g)
+		commands, err := m.GetCommands(ctx, g)
 		if err != nil {
-			w.Update(corde.NewResp().Contentf("Error getting commands: %s", err.Error()).Ephemeral())
-			return
+			return corde.NewUserError("Error getting commands", err)
 		}
-		c := commands[*selectedID]
+		if len(commands) == 0 {
+			return corde.UserErrorf("No commands found.")
+		}
+		c := commands[*selectedID%len(commands)]
 
-		m.DeleteCommand(c.ID, g)
+		if err := m.DeleteCommand(ctx, c.ID, g); err != nil {
+			return corde.NewUserError("Error deleting command", err)
+		}
 
-		commands, _ = m.GetCommands(g)
+		commands, _ = m.GetCommands(ctx, g)
 		if len(commands) == 0 {
 			w.Update(corde.NewResp().Content("No commands found.").Ephemeral())
-			return
+			return nil
 		}
 
 		*selectedID = (*selectedID + 1) % len(commands)
@@ -129,5 +133,6 @@ func btnRemove(m *corde.Mux, g func(*corde.CommandsOpt), mu *sync.Mutex, selecte
 			ActionRow(nextBtn, delBtn).
 			Ephemeral(),
 		)
+		return nil
 	}
 }
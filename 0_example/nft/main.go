@@ -35,7 +35,7 @@ func main() {
 	m := corde.NewMux(pk, appID, token)
 
 	// user
-	if err := m.BulkRegisterCommand(commands, g); err != nil {
+	if _, err := m.BulkRegisterCommand(context.Background(), commands, g); err != nil {
 		log.Fatalln("error registering command: ", err)
 	}
 
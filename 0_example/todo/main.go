@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"sync"
@@ -40,7 +41,7 @@ func main() {
 	}
 
 	m := corde.NewMux(pk, appID, token)
-	m.Route("todo", func(m *corde.Mux) {
+	m.Command(commands, func(m *corde.Mux) {
 		m.SlashCommand("add", t.addHandler)
 		m.SlashCommand("list", t.listHandler)
 		m.Route("rm", func(m *corde.Mux) {
@@ -49,8 +50,13 @@ func main() {
 		})
 	})
 
+	cmds, err := m.Commands()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
 	g := corde.GuildOpt(corde.SnowflakeFromString(os.Getenv("DISCORD_GUILD_ID")))
-	if err := m.RegisterCommand(commands, g); err != nil {
+	if _, err := m.BulkRegisterCommand(context.Background(), cmds, g); err != nil {
 		log.Fatalln(err)
 	}
 
@@ -0,0 +1,170 @@
+package corde
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ErrorCode is a JSON error code returned by discord
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+type ErrorCode int
+
+const (
+	ERROR_GENERAL                          ErrorCode = 0
+	ERROR_UNKNOWN_CHANNEL                  ErrorCode = 10003
+	ERROR_UNKNOWN_GUILD                    ErrorCode = 10004
+	ERROR_UNKNOWN_MEMBER                   ErrorCode = 10007
+	ERROR_UNKNOWN_MESSAGE                  ErrorCode = 10008
+	ERROR_UNKNOWN_ROLE                     ErrorCode = 10011
+	ERROR_UNKNOWN_USER                     ErrorCode = 10013
+	ERROR_UNKNOWN_WEBHOOK                  ErrorCode = 10015
+	ERROR_UNKNOWN_INTERACTION              ErrorCode = 10062
+	ERROR_UNKNOWN_APPLICATION_COMMAND      ErrorCode = 10063
+	ERROR_INTERACTION_ALREADY_ACKNOWLEDGED ErrorCode = 40060
+	ERROR_MISSING_ACCESS                   ErrorCode = 50001
+	ERROR_CANNOT_SEND_TO_USER              ErrorCode = 50007
+	ERROR_MISSING_PERMISSIONS              ErrorCode = 50013
+	ERROR_INVALID_FORM_BODY                ErrorCode = 50035
+)
+
+// APIError is an error response of the discord API
+type APIError struct {
+	Status  int          `json:"-"` // the HTTP status code
+	Code    ErrorCode    `json:"code"`
+	Message string       `json:"message"`
+	Errors  *FieldErrors `json:"errors,omitempty"` // the errors of the fields of the request body, if any
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "corde: discord API error %d (status %d): %s", e.Code, e.Status, e.Message)
+
+	fields := e.Errors.Flatten()
+	paths := make([]string, 0, len(fields))
+	for p := range fields {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		for _, fe := range fields[p] {
+			fmt.Fprintf(b, "; %s: %s", p, fe.Message)
+		}
+	}
+
+	return b.String()
+}
+
+// FieldError is the error of a field of the request body
+type FieldError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// FieldErrors is the tree of errors of the request body,
+// following the structure of the request, such as `options.0.name`
+type FieldErrors struct {
+	Errors []FieldError            // the errors of this field
+	Fields map[string]*FieldErrors // the errors of the nested fields, by key or index
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *FieldErrors) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	for k, v := range raw {
+		if k == "_errors" {
+			if err := json.Unmarshal(v, &f.Errors); err != nil {
+				return err
+			}
+			continue
+		}
+
+		nested := &FieldErrors{}
+		if err := json.Unmarshal(v, nested); err != nil {
+			return err
+		}
+		if f.Fields == nil {
+			f.Fields = map[string]*FieldErrors{}
+		}
+		f.Fields[k] = nested
+	}
+
+	return nil
+}
+
+// Flatten returns the errors of the tree by the dotted path of their field
+func (f *FieldErrors) Flatten() map[string][]FieldError {
+	flat := map[string][]FieldError{}
+	f.flatten("", flat)
+	return flat
+}
+
+func (f *FieldErrors) flatten(prefix string, flat map[string][]FieldError) {
+	if f == nil {
+		return
+	}
+	if len(f.Errors) > 0 {
+		flat[prefix] = append(flat[prefix], f.Errors...)
+	}
+	for k, nested := range f.Fields {
+		p := k
+		if prefix != "" {
+			p = prefix + "." + k
+		}
+		nested.flatten(p, flat)
+	}
+}
+
+// newAPIError reads the error of the response.
+// Bodies which aren't discord errors are kept as the message.
+func newAPIError(resp *http.Response) *APIError {
+	e := &APIError{Status: resp.StatusCode}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil || json.Unmarshal(b, e) != nil || e.Message == "" {
+		e.Message = strings.TrimSpace(string(b))
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return e
+}
+
+// IsErrorCode reports wether err is an APIError with the code
+func IsErrorCode(err error, code ErrorCode) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// IsUnknownInteraction reports wether err is due to an unknown interaction,
+// such as one whose token expired
+func IsUnknownInteraction(err error) bool {
+	return IsErrorCode(err, ERROR_UNKNOWN_INTERACTION)
+}
+
+// IsMissingPermissions reports wether err is due to the bot lacking permissions
+func IsMissingPermissions(err error) bool {
+	return IsErrorCode(err, ERROR_MISSING_PERMISSIONS)
+}
+
+// IsMissingAccess reports wether err is due to the bot lacking access to a resource
+func IsMissingAccess(err error) bool {
+	return IsErrorCode(err, ERROR_MISSING_ACCESS)
+}
+
+// IsNotFound reports wether err is an APIError with a 404 status
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
@@ -0,0 +1,59 @@
+package corde_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Karitham/corde"
+	"github.com/matryer/is"
+)
+
+func TestAPIError(t *testing.T) {
+	assert := is.New(t)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": 50035, "message": "Invalid Form Body", "errors": {"options": {"0": {"name": {"_errors": [{"code": "BASE_TYPE_MAX_LENGTH", "message": "Must be 32 or fewer in length."}]}}}}}`))
+		case http.MethodPatch:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Unknown interaction", "code": 10062}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message": "Missing Permissions", "code": 50013}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`upstream unavailable`))
+		}
+	}))
+	defer api.Close()
+
+	m := corde.NewMux("", 1234, "", corde.APIRootOpt(api.URL))
+	m.Client = api.Client()
+	ctx := context.Background()
+
+	_, err := m.RegisterCommand(ctx, corde.NewSlashCommand("ping", "ping the bot"))
+	var apiErr *corde.APIError
+	assert.True(errors.As(err, &apiErr))
+	assert.Equal(apiErr.Status, http.StatusBadRequest)
+	assert.Equal(apiErr.Code, corde.ERROR_INVALID_FORM_BODY)
+	assert.Equal(apiErr.Errors.Flatten()["options.0.name"][0].Code, "BASE_TYPE_MAX_LENGTH")
+	assert.Equal(err.Error(), "corde: discord API error 50035 (status 400): Invalid Form Body; options.0.name: Must be 32 or fewer in length.")
+
+	err = m.EditOriginalInteraction(ctx, "token", corde.NewResp().Content("edited"))
+	assert.True(corde.IsUnknownInteraction(err))
+	assert.True(corde.IsNotFound(err))
+	assert.True(!corde.IsMissingPermissions(err))
+
+	err = m.DeleteCommand(ctx, 42)
+	assert.True(corde.IsMissingPermissions(err))
+
+	_, err = m.Me(ctx)
+	assert.True(errors.As(err, &apiErr))
+	assert.Equal(apiErr.Status, http.StatusBadGateway)
+	assert.Equal(apiErr.Message, "upstream unavailable")
+}
@@ -0,0 +1,74 @@
+package corde
+
+import (
+	"context"
+	"time"
+)
+
+// responderKey is the context key holding the Responder of the interaction being routed
+type responderKey struct{}
+
+// autoDefer defers the interaction if nothing was written after the given duration.
+// Components are defered as updates, other interactions as responses.
+//
+// It returns a function stopping the timer, to call once the handler returns.
+func autoDefer(ctx context.Context, after time.Duration, typ InnerInteractionType) func() {
+	rsp, ok := ctx.Value(responderKey{}).(*Responder)
+	if !ok {
+		return func() {}
+	}
+
+	t := time.AfterFunc(after, func() {
+		switch typ {
+		case ActionRowInteraction, ButtonInteraction, SelectMenuInteraction, TextInputInteraction:
+			rsp.autoDefer(6)
+		default:
+			rsp.autoDefer(5)
+		}
+	})
+
+	return func() { t.Stop() }
+}
+
+// autoDefer defers the interaction with the given response type, if it wasn't answered yet
+func (r *Responder) autoDefer(typ int) {
+	r.write(typ, stateDeferred, func() error {
+		r.autoDeferred = true
+		return r.encode(intResponse{Type: typ})
+	}, nil)
+}
+
+// autoDeferred reports wether the interaction was deferred by the mux rather than by its handler
+func autoDeferred(w ResponseWriter) bool {
+	rsp, ok := w.(*Responder)
+	if !ok {
+		return false
+	}
+
+	rsp.mu.Lock()
+	defer rsp.mu.Unlock()
+	return rsp.autoDeferred
+}
+
+// nodeAutoDefer returns after how long interactions of the type are auto-deferred on the node,
+// autocompletes are never deferred
+func (m *Mux) nodeAutoDefer(node *routeNode, typ InnerInteractionType) time.Duration {
+	if typ == AutocompleteInteraction {
+		return 0
+	}
+	if node.autoDefer != 0 {
+		return node.autoDefer
+	}
+	return m.AutoDefer
+}
+
+// detachedContext keeps the values of its parent, but not its deadline nor its cancellation,
+// so that handlers can outlive the HTTP request
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key any) any         { return c.parent.Value(key) }
@@ -0,0 +1,106 @@
+package corde_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Karitham/corde"
+	"github.com/Karitham/corde/owmock"
+	"github.com/matryer/is"
+)
+
+func TestAutoDefer(t *testing.T) {
+	assert := is.New(t)
+
+	edits := make(chan corde.InteractionRespData, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(r.Method, http.MethodPatch)
+		assert.Equal(r.URL.Path, "/v10/webhooks/290926444748734465/unique_interaction_token/messages/@original")
+
+		var data corde.InteractionRespData
+		assert.NoErr(json.NewDecoder(r.Body).Decode(&data))
+		edits <- data
+	}))
+	defer api.Close()
+
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 290926444748734465, "", corde.APIRootOpt(api.URL))
+	mux.AutoDefer = 10 * time.Millisecond
+	mux.ButtonComponent("click_one", func(ctx context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) {
+		time.Sleep(100 * time.Millisecond)
+		assert.NoErr(ctx.Err()) // the context outlives the HTTP request
+		w.Update(corde.NewResp().Content("Hello World!"))
+	})
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleComponent, &owmock.InteractionResponse{Type: 6})
+	assert.NoErr(err)
+
+	select {
+	case data := <-edits:
+		assert.Equal(data.Content, "Hello World!")
+	case <-time.After(time.Second):
+		t.Fatal("expected the deferred response to be edited")
+	}
+}
+
+func TestAutoDeferInTime(t *testing.T) {
+	assert := is.New(t)
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 0, "")
+	mux.Route("click_one", func(m *corde.Mux) {
+		m.AutoDefer = time.Second
+		m.ButtonComponent("", func(_ context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) {
+			w.Respond(corde.NewResp().Content("Hello World!"))
+		})
+	})
+
+	expect := &owmock.InteractionResponse{
+		Type: 4,
+		Data: corde.InteractionRespData{
+			Content: "Hello World!",
+		},
+	}
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleComponent, expect)
+	assert.NoErr(err)
+}
+
+func TestAutoDeferNoResponse(t *testing.T) {
+	assert := is.New(t)
+
+	edits := make(chan string, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data corde.InteractionRespData
+		assert.NoErr(json.NewDecoder(r.Body).Decode(&data))
+		edits <- r.Method + " " + r.URL.Path + " " + data.Content
+	}))
+	defer api.Close()
+
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 290926444748734465, "", corde.APIRootOpt(api.URL))
+	mux.AutoDefer = 10 * time.Millisecond
+	mux.SlashCommand("ban", func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.SlashCommandInteractionData]) {
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, fmt.Sprintf(SampleCommandInteraction, SampleBanCommand), &owmock.InteractionResponse{Type: 5})
+	assert.NoErr(err)
+
+	select {
+	case e := <-edits:
+		assert.Equal(e, "PATCH /v10/webhooks/290926444748734465/unique_interaction_token/messages/@original "+corde.DefaultNoResponseMessage)
+	case <-time.After(time.Second):
+		t.Fatal("expected the deferred response to be edited")
+	}
+}
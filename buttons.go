@@ -25,28 +25,35 @@ const (
 	COMPONENT_BUTTON
 	COMPONENT_SELECT_MENU
 	COMPONENT_TEXT_INPUT
+	COMPONENT_USER_SELECT
+	COMPONENT_ROLE_SELECT
+	COMPONENT_MENTIONABLE_SELECT
+	COMPONENT_CHANNEL_SELECT
+
+	COMPONENT_STRING_SELECT = COMPONENT_SELECT_MENU
 )
 
 // Component
 //
 // https://discord.com/developers/docs/interactions/message-components#component-object-component-types
 type Component struct {
-	Type        ComponentType `json:"type"`
-	CustomID    string        `json:"custom_id"`
-	Style       Style         `json:"style,omitempty"`
-	Disabled    bool          `json:"disabled,omitempty"`
-	Label       string        `json:"label,omitempty"`
-	Emoji       *Emoji        `json:"emoji,omitempty"`
-	URL         string        `json:"url,omitempty"`
-	Placeholder string        `json:"placeholder,omitempty"`
-	MinValues   int           `json:"min_values,omitempty"`
-	MaxValues   int           `json:"max_values,omitempty"`
-	MinLength   int           `json:"min_length,omitempty"`
-	MaxLength   int           `json:"max_length,omitempty"`
-	Required    bool          `json:"required,omitempty"`
-	Value       string        `json:"value,omitempty"`
-	Options     []Option      `json:"options,omitempty"`
-	Components  []Component   `json:"components,omitempty"`
+	Type         ComponentType  `json:"type"`
+	CustomID     string         `json:"custom_id"`
+	Style        Style          `json:"style,omitempty"`
+	Disabled     bool           `json:"disabled,omitempty"`
+	Label        string         `json:"label,omitempty"`
+	Emoji        *Emoji         `json:"emoji,omitempty"`
+	URL          string         `json:"url,omitempty"`
+	Placeholder  string         `json:"placeholder,omitempty"`
+	MinValues    int            `json:"min_values,omitempty"`
+	MaxValues    int            `json:"max_values,omitempty"`
+	MinLength    int            `json:"min_length,omitempty"`
+	MaxLength    int            `json:"max_length,omitempty"`
+	Required     bool           `json:"required,omitempty"`
+	Value        string         `json:"value,omitempty"`
+	Options      []SelectOption `json:"options,omitempty"`
+	ChannelTypes []ChannelType  `json:"channel_types,omitempty"`
+	Components   []Component    `json:"components,omitempty"`
 }
 
 func (c Component) Component() Component {
@@ -0,0 +1,76 @@
+package corde
+
+import (
+	"context"
+
+	"github.com/Karitham/corde/internal/rest"
+)
+
+// CommandPermissionType is the type of the entity a CommandPermission applies to
+type CommandPermissionType int
+
+const (
+	COMMAND_PERMISSION_ROLE CommandPermissionType = iota + 1
+	COMMAND_PERMISSION_USER
+	COMMAND_PERMISSION_CHANNEL
+)
+
+// CommandPermission allows or denies a role, a user or a channel to use a command
+// https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object-application-command-permissions-structure
+type CommandPermission struct {
+	// ID of the role, user or channel.
+	// Use the guild ID for @everyone, and AllChannels for every channel of the guild
+	ID         Snowflake             `json:"id"`
+	Type       CommandPermissionType `json:"type"`
+	Permission bool                  `json:"permission"` // wether the entity is allowed to use the command
+}
+
+// GuildCommandPermissions are the permissions of a command in a guild
+// https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object-guild-application-command-permissions-structure
+type GuildCommandPermissions struct {
+	ID            Snowflake           `json:"id"` // the ID of the command, or of the application for app-wide permissions
+	ApplicationID Snowflake           `json:"application_id"`
+	GuildID       Snowflake           `json:"guild_id"`
+	Permissions   []CommandPermission `json:"permissions"`
+}
+
+// AllChannels returns the ID standing for every channel of the guild in a CommandPermission
+func AllChannels(guildID Snowflake) Snowflake {
+	return guildID - 1
+}
+
+// GetGuildCommandPermissions returns the permissions of all the commands of the application in the guild
+func (m *Mux) GetGuildCommandPermissions(ctx context.Context, guildID Snowflake) ([]GuildCommandPermissions, error) {
+	r := m.api.Req(ctx, "applications", m.AppID, "guilds", guildID, "commands", "permissions")
+
+	var perms []GuildCommandPermissions
+	if err := m.do(r.Get(m.authorize, rest.JSON), &perms); err != nil {
+		return nil, err
+	}
+	return perms, nil
+}
+
+// GetCommandPermissions returns the permissions of a command in the guild
+func (m *Mux) GetCommandPermissions(ctx context.Context, guildID Snowflake, ID Snowflake) (GuildCommandPermissions, error) {
+	r := m.api.Req(ctx, "applications", m.AppID, "guilds", guildID, "commands", ID, "permissions")
+
+	var perms GuildCommandPermissions
+	err := m.do(r.Get(m.authorize, rest.JSON), &perms)
+	return perms, err
+}
+
+// EditCommandPermissions overwrites the permissions of a command in the guild.
+//
+// Discord doesn't allow bots to edit command permissions with their bot token,
+// so it needs the bearer token of a user allowed to manage the guild and its roles,
+// granted with the `applications.commands.permissions.update` scope.
+func (m *Mux) EditCommandPermissions(ctx context.Context, guildID Snowflake, ID Snowflake, bearerToken string, perms []CommandPermission) (GuildCommandPermissions, error) {
+	r := m.api.Req(ctx, "applications", m.AppID, "guilds", guildID, "commands", ID, "permissions").
+		JSONBody(struct {
+			Permissions []CommandPermission `json:"permissions"`
+		}{perms})
+
+	var updated GuildCommandPermissions
+	err := m.do(r.Put(rest.BearerAuthorization(bearerToken), rest.JSON), &updated)
+	return updated, err
+}
@@ -0,0 +1,83 @@
+package corde_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Karitham/corde"
+	"github.com/matryer/is"
+)
+
+func TestCommandPermissions(t *testing.T) {
+	assert := is.New(t)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			assert.Equal(r.URL.Path, "/v10/applications/1234/guilds/5678/commands/42/permissions")
+			assert.Equal(r.Header.Get("authorization"), "Bearer user_token")
+
+			var body struct {
+				Permissions []corde.CommandPermission `json:"permissions"`
+			}
+			assert.NoErr(json.NewDecoder(r.Body).Decode(&body))
+			json.NewEncoder(w).Encode(corde.GuildCommandPermissions{ID: 42, ApplicationID: 1234, GuildID: 5678, Permissions: body.Permissions})
+		case http.MethodGet:
+			assert.Equal(r.URL.Path, "/v10/applications/1234/guilds/5678/commands/permissions")
+			assert.Equal(r.Header.Get("authorization"), "Bot bot_token")
+			w.Write([]byte(`[{"id": "42", "application_id": "1234", "guild_id": "5678", "permissions": [{"id": "5677", "type": 3, "permission": false}]}]`))
+		}
+	}))
+	defer api.Close()
+
+	m := corde.NewMux("", 1234, "bot_token", corde.APIRootOpt(api.URL))
+	m.Client = api.Client()
+	ctx := context.Background()
+
+	perms, err := m.EditCommandPermissions(ctx, 5678, 42, "user_token", []corde.CommandPermission{
+		{ID: 91011, Type: corde.COMMAND_PERMISSION_ROLE, Permission: true},
+	})
+	assert.NoErr(err)
+	assert.Equal(perms.Permissions[0].ID, corde.Snowflake(91011))
+
+	all, err := m.GetGuildCommandPermissions(ctx, 5678)
+	assert.NoErr(err)
+	assert.Equal(len(all), 1)
+	assert.Equal(all[0].Permissions[0], corde.CommandPermission{ID: corde.AllChannels(5678), Type: corde.COMMAND_PERMISSION_CHANNEL})
+}
+
+func TestCommandDefaultPermissions(t *testing.T) {
+	assert := is.New(t)
+
+	cmd := corde.NewSlashCommand("ban", "ban a member").
+		RequirePermissions(corde.PERMISSION_BAN_MEMBERS | corde.PERMISSION_KICK_MEMBERS).
+		GuildOnly()
+
+	b, err := json.Marshal(cmd)
+	assert.NoErr(err)
+
+	var got corde.Command
+	assert.NoErr(json.Unmarshal(b, &got))
+	assert.Equal(got.DefaultMemberPermissions.String(), "6")
+	assert.True(got.DefaultMemberPermissions.Has(corde.PERMISSION_BAN_MEMBERS))
+	assert.True(!got.DefaultMemberPermissions.Has(corde.PERMISSION_ADMINISTRATOR))
+	assert.Equal(*got.DMPermission, false)
+}
+
+func TestCommandDefaultPermissionOmitted(t *testing.T) {
+	assert := is.New(t)
+
+	b, err := json.Marshal(corde.NewSlashCommand("ban", "ban a member"))
+	assert.NoErr(err)
+	assert.True(!strings.Contains(string(b), "default_permission")) // the deprecated field isn't sent unless set
+
+	cmd := corde.NewSlashCommand("ban", "ban a member")
+	cmd.DefaultPermissionDisabled = true
+	b, err = json.Marshal(cmd)
+	assert.NoErr(err)
+	assert.True(strings.Contains(string(b), `"default_permission":false`))
+}
@@ -1,12 +1,15 @@
 package corde
 
 import (
+	"context"
+
 	"github.com/Karitham/corde/internal/rest"
 )
 
 // CommandsOpt is an option for a Command
 type CommandsOpt struct {
 	guildID Snowflake
+	dryRun  bool
 }
 
 // GuildOpt is an option for setting the guild of a Command
@@ -16,85 +19,101 @@ func GuildOpt(guildID Snowflake) func(*CommandsOpt) {
 	}
 }
 
-// GetCommands returns a slice of Command from the Mux
-func (m *Mux) GetCommands(options ...func(*CommandsOpt)) ([]Command, error) {
+// GetCommands returns a slice of Command from the Mux, including their localizations
+func (m *Mux) GetCommands(ctx context.Context, options ...func(*CommandsOpt)) ([]Command, error) {
 	opt := &CommandsOpt{}
 	for _, option := range options {
 		option(opt)
 	}
 
-	r := rest.Req("applications", m.AppID)
-	if opt.guildID != 0 {
-		r.Append("guilds", opt.guildID)
-	}
-	r.Append("commands")
+	r := m.commandsReq(ctx, opt).Query("with_localizations", "true")
 
 	var commands []Command
-	_, err := rest.DoJSON(m.Client, r.Get(m.authorize, rest.JSON), &commands)
-	if err != nil {
+	if err := m.do(r.Get(m.authorize, rest.JSON), &commands); err != nil {
 		return nil, err
 	}
 
 	return commands, nil
 }
 
-// RegisterCommand registers a new Command on discord
-func (m *Mux) RegisterCommand(c CreateCommander, options ...func(*CommandsOpt)) error {
+// GetCommand returns a single Command from discord
+func (m *Mux) GetCommand(ctx context.Context, ID Snowflake, options ...func(*CommandsOpt)) (Command, error) {
 	opt := &CommandsOpt{}
 	for _, option := range options {
 		option(opt)
 	}
 
-	r := rest.Req("applications", m.AppID).JSONBody(c)
-	if opt.guildID != 0 {
-		r.Append("guilds", opt.guildID)
-	}
-	r.Append("commands")
+	r := m.commandsReq(ctx, opt).Append(ID)
+
+	var command Command
+	err := m.do(r.Get(m.authorize, rest.JSON), &command)
+	return command, err
+}
 
-	resp, err := m.Client.Do(r.Post(m.authorize, rest.JSON))
-	if err != nil {
-		return err
+// RegisterCommand registers a new Command on discord, returning it as created by discord.
+//
+// Registering a Command with the name of an existing one overwrites it.
+func (m *Mux) RegisterCommand(ctx context.Context, c CreateCommander, options ...func(*CommandsOpt)) (Command, error) {
+	opt := &CommandsOpt{}
+	for _, option := range options {
+		option(opt)
 	}
-	return rest.CodeBetween(resp, 200, 299)
+
+	r := m.commandsReq(ctx, opt).JSONBody(c)
+
+	var command Command
+	err := m.do(r.Post(m.authorize, rest.JSON), &command)
+	return command, err
 }
 
-// BulkRegisterCommand registers a slice of Command on discord
-func (m *Mux) BulkRegisterCommand(c []CreateCommander, options ...func(*CommandsOpt)) error {
+// EditCommand edits an existing Command on discord, returning it as updated by discord
+func (m *Mux) EditCommand(ctx context.Context, ID Snowflake, c CreateCommander, options ...func(*CommandsOpt)) (Command, error) {
 	opt := &CommandsOpt{}
 	for _, option := range options {
 		option(opt)
 	}
 
-	r := rest.Req("applications", m.AppID).JSONBody(c)
-	if opt.guildID != 0 {
-		r.Append("guilds", opt.guildID)
+	r := m.commandsReq(ctx, opt).Append(ID).JSONBody(c)
+
+	var command Command
+	err := m.do(r.Patch(m.authorize, rest.JSON), &command)
+	return command, err
+}
+
+// BulkRegisterCommand overwrites the registered commands with a slice of Command,
+// returning them as registered by discord
+func (m *Mux) BulkRegisterCommand(ctx context.Context, c []CreateCommander, options ...func(*CommandsOpt)) ([]Command, error) {
+	opt := &CommandsOpt{}
+	for _, option := range options {
+		option(opt)
 	}
-	r.Append("commands")
 
-	resp, err := m.Client.Do(r.Put(m.authorize, rest.JSON))
-	if err != nil {
-		return err
+	r := m.commandsReq(ctx, opt).JSONBody(c)
+
+	var commands []Command
+	if err := m.do(r.Put(m.authorize, rest.JSON), &commands); err != nil {
+		return nil, err
 	}
-	return rest.CodeBetween(resp, 200, 299)
+	return commands, nil
 }
 
 // DeleteCommand deletes a Command from discord
-func (m *Mux) DeleteCommand(ID Snowflake, options ...func(*CommandsOpt)) error {
+func (m *Mux) DeleteCommand(ctx context.Context, ID Snowflake, options ...func(*CommandsOpt)) error {
 	opt := &CommandsOpt{}
 	for _, option := range options {
 		option(opt)
 	}
 
-	r := rest.Req("applications", m.AppID)
+	r := m.commandsReq(ctx, opt).Append(ID)
+
+	return m.do(r.Delete(m.authorize, rest.JSON), nil)
+}
+
+// commandsReq returns the request to the commands of the application
+func (m *Mux) commandsReq(ctx context.Context, opt *CommandsOpt) *rest.Request {
+	r := m.api.Req(ctx, "applications", m.AppID)
 	if opt.guildID != 0 {
 		r.Append("guilds", opt.guildID)
 	}
-	r.Append("commands", ID)
-
-	resp, err := m.Client.Do(r.Delete(m.authorize, rest.JSON))
-	if err != nil {
-		return err
-	}
-
-	return rest.ExpectCode(resp, 204)
+	return r.Append("commands")
 }
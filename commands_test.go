@@ -0,0 +1,164 @@
+package corde_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Karitham/corde"
+	"github.com/matryer/is"
+)
+
+func TestCommandsCRUD(t *testing.T) {
+	assert := is.New(t)
+
+	var calls []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		switch r.Method {
+		case http.MethodPut:
+			var cmds []corde.Command
+			assert.NoErr(json.NewDecoder(r.Body).Decode(&cmds))
+			for i := range cmds {
+				cmds[i].ID = corde.Snowflake(i + 1)
+			}
+			json.NewEncoder(w).Encode(cmds)
+		case http.MethodPost, http.MethodPatch:
+			var cmd corde.Command
+			assert.NoErr(json.NewDecoder(r.Body).Decode(&cmd))
+			cmd.ID = 42
+			json.NewEncoder(w).Encode(cmd)
+		case http.MethodGet:
+			if r.URL.Path == "/v10/applications/1234/commands/404" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"message": "Unknown application command", "code": 10063}`))
+				return
+			}
+			json.NewEncoder(w).Encode(corde.Command{ID: 42, Name: "ping"})
+		}
+	}))
+	defer api.Close()
+
+	m := corde.NewMux("", 1234, "", corde.APIRootOpt(api.URL))
+	m.Client = api.Client()
+	ctx := context.Background()
+	g := corde.GuildOpt(5678)
+
+	cmd, err := m.RegisterCommand(ctx, corde.NewSlashCommand("ping", "ping the bot"), g)
+	assert.NoErr(err)
+	assert.Equal(cmd.ID, corde.Snowflake(42))
+	assert.Equal(cmd.Name, "ping")
+
+	cmd, err = m.EditCommand(ctx, 42, corde.NewSlashCommand("ping", "ping the bot again"), g)
+	assert.NoErr(err)
+	assert.Equal(cmd.Description, "ping the bot again")
+
+	cmd, err = m.GetCommand(ctx, 42, g)
+	assert.NoErr(err)
+	assert.Equal(cmd.Name, "ping")
+
+	_, err = m.GetCommand(ctx, 404)
+	assert.True(err != nil)
+
+	cmds, err := m.BulkRegisterCommand(ctx, []corde.CreateCommander{
+		corde.NewSlashCommand("ping", "ping the bot"),
+		corde.NewUserCommand("Wave"),
+	})
+	assert.NoErr(err)
+	assert.Equal(len(cmds), 2)
+	assert.Equal(cmds[1].ID, corde.Snowflake(2))
+
+	assert.Equal(calls, []string{
+		"POST /v10/applications/1234/guilds/5678/commands",
+		"PATCH /v10/applications/1234/guilds/5678/commands/42",
+		"GET /v10/applications/1234/guilds/5678/commands/42",
+		"GET /v10/applications/1234/commands/404",
+		"PUT /v10/applications/1234/commands",
+	})
+}
+
+func TestRESTContext(t *testing.T) {
+	assert := is.New(t)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer api.Close()
+
+	m := corde.NewMux("", 1234, "", corde.APIRootOpt(api.URL))
+	m.Client = api.Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := m.GetCommands(ctx)
+	assert.True(errors.Is(err, context.Canceled))
+
+	err = m.FollowUpInteraction(ctx, "token", corde.NewResp().Content("too late"))
+	assert.True(errors.Is(err, context.Canceled))
+}
+
+func TestMuxAPIOptions(t *testing.T) {
+	assert := is.New(t)
+
+	newAPI := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode([]corde.Command{{Name: name + " " + r.URL.Path + " " + r.UserAgent()}})
+		}))
+	}
+	mock, other := newAPI("mock"), newAPI("other")
+	defer mock.Close()
+	defer other.Close()
+
+	m := corde.NewMux("", 1234, "",
+		corde.APIRootOpt(mock.URL+"/api"),
+		corde.APIVersionOpt(9),
+		corde.UserAgentOpt("DiscordBot (https://example.com, 1.0)"),
+		corde.HTTPClientOpt(mock.Client()),
+	)
+	o := corde.NewMux("", 1234, "", corde.APIRootOpt(other.URL), corde.HTTPClientOpt(other.Client()))
+
+	cmds, err := m.GetCommands(context.Background())
+	assert.NoErr(err)
+	assert.Equal(cmds[0].Name, "mock /api/v9/applications/1234/commands DiscordBot (https://example.com, 1.0)")
+
+	cmds, err = o.GetCommands(context.Background())
+	assert.NoErr(err)
+	assert.Equal(cmds[0].Name, "other /v10/applications/1234/commands DiscordBot (https://github.com/Karitham/corde)")
+}
+
+func TestMuxRetry(t *testing.T) {
+	assert := is.New(t)
+
+	var calls int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(corde.Command{ID: 42, Name: "ping"})
+	}))
+	defer api.Close()
+
+	m := corde.NewMux("", 1234, "",
+		corde.APIRootOpt(api.URL),
+		corde.HTTPClientOpt(api.Client()),
+		corde.RetryOpt(corde.RetryPolicy{MinBackoff: time.Millisecond}),
+	)
+
+	cmd, err := m.GetCommand(context.Background(), 42)
+	assert.NoErr(err)
+	assert.Equal(cmd.Name, "ping")
+	assert.Equal(calls, 2)
+
+	calls = 0
+	_, err = m.RegisterCommand(context.Background(), corde.NewSlashCommand("ping", "ping the bot"))
+	assert.True(err != nil)
+	assert.Equal(calls, 1)
+}
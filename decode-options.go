@@ -0,0 +1,254 @@
+package corde
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// OptionsResolver is the data of the interactions carrying options,
+// such as slash commands and autocompletes
+type OptionsResolver interface {
+	optionsResolved() (OptionsInteractions, Resolved)
+}
+
+func (i resolvedInteractionWithOptions) optionsResolved() (OptionsInteractions, Resolved) {
+	return i.Options, i.Resolved
+}
+
+func (a AutocompleteInteractionData) optionsResolved() (OptionsInteractions, Resolved) {
+	return a.Options, Resolved{}
+}
+
+// ErrMissingOption is wrapped by the errors of required options missing from the interaction
+var ErrMissingOption = errors.New("missing required option")
+
+// OptionError is an error decoding a single option
+type OptionError struct {
+	Option string // the name of the option
+	Field  string // the name of the struct field
+	Err    error
+}
+
+// Error implements error
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("option %q (field %s): %s", e.Option, e.Field, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+// OptionsError holds every error encountered while decoding options
+type OptionsError []*OptionError
+
+// Error implements error
+func (e OptionsError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return "corde: decoding options: " + strings.Join(msgs, "; ")
+}
+
+// InvalidOptionsError is returned by typed handlers when the options of the interaction can't be decoded into their arguments.
+// Unlike internal errors, the default error handler tells the user which options are invalid.
+type InvalidOptionsError struct {
+	Err OptionsError
+}
+
+// Error implements error
+func (e *InvalidOptionsError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying OptionsError
+func (e *InvalidOptionsError) Unwrap() error {
+	return e.Err
+}
+
+// Message returns the message shown to the user, listing the invalid options
+func (e *InvalidOptionsError) Message() string {
+	msgs := make([]string, 0, len(e.Err))
+	for _, err := range e.Err {
+		if errors.Is(err, ErrMissingOption) {
+			msgs = append(msgs, fmt.Sprintf("`%s` is required", err.Option))
+			continue
+		}
+		msgs = append(msgs, fmt.Sprintf("`%s` is invalid", err.Option))
+	}
+	return "Invalid options: " + strings.Join(msgs, ", ") + "."
+}
+
+var (
+	snowflakeType   = reflect.TypeOf(Snowflake(0))
+	userType        = reflect.TypeOf(User{})
+	memberType      = reflect.TypeOf(Member{})
+	roleType        = reflect.TypeOf(Role{})
+	messageType     = reflect.TypeOf(Message{})
+	channelType     = reflect.TypeOf(Channel{})
+	attachmentType  = reflect.TypeOf(Attachment{})
+	mentionableType = reflect.TypeOf(Mentionable{})
+)
+
+// DecodeOptions decodes the options of the interaction into v, which must be a pointer to a struct.
+//
+// Fields are matched to options using the `corde` struct tag, such as
+//
+//	type BanArgs struct {
+//		Member corde.Member `corde:"member,required"`
+//		Reason *string      `corde:"reason"`
+//		Days   int          `corde:"days"`
+//	}
+//
+// Fields without a tag use their lowercased name, and fields tagged `corde:"-"` are skipped.
+// Optional options missing from the interaction leave their field untouched,
+// which is nil for pointer fields.
+//
+// Snowflake options can be decoded as a Snowflake, or resolved into a User, Member, Role, Mentionable, Message, Channel or Attachment.
+//
+// Missing required options and mismatched types are all reported in a single OptionsError.
+func DecodeOptions(data OptionsResolver, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("corde: DecodeOptions expects a non-nil pointer to a struct, got %T", v)
+	}
+
+	opts, resolved := data.optionsResolved()
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	var errs OptionsError
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag, ok := parseOptionTag(f)
+		if !ok {
+			continue
+		}
+
+		raw, ok := opts[tag.name]
+		if !ok {
+			if tag.required {
+				errs = append(errs, &OptionError{Option: tag.name, Field: f.Name, Err: ErrMissingOption})
+			}
+			continue
+		}
+
+		if err := decodeOption(rv.Field(i), raw, resolved); err != nil {
+			errs = append(errs, &OptionError{Option: tag.name, Field: f.Name, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// optionTag is a parsed `corde` struct tag
+type optionTag struct {
+	name         string
+	required     bool
+	autocomplete bool
+	params       map[string]string // key=value parameters
+}
+
+// parseOptionTag parses the `corde` tag of the field.
+// It reports false if the field should be skipped.
+func parseOptionTag(f reflect.StructField) (optionTag, bool) {
+	if !f.IsExported() {
+		return optionTag{}, false
+	}
+
+	tag, _ := f.Tag.Lookup("corde")
+	if tag == "-" {
+		return optionTag{}, false
+	}
+
+	parts := strings.Split(tag, ",")
+	t := optionTag{name: parts[0], params: map[string]string{}}
+	if t.name == "" {
+		t.name = strings.ToLower(f.Name)
+	}
+
+	for _, p := range parts[1:] {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			t.params[k] = v
+			continue
+		}
+		switch p {
+		case "required":
+			t.required = true
+		case "autocomplete":
+			t.autocomplete = true
+		}
+	}
+
+	return t, true
+}
+
+// decodeOption decodes the raw option value into the field,
+// resolving snowflakes into their entities if needed
+func decodeOption(field reflect.Value, raw JsonRaw, resolved Resolved) error {
+	if field.Kind() == reflect.Pointer {
+		ptr := reflect.New(field.Type().Elem())
+		if err := decodeOption(ptr.Elem(), raw, resolved); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	var resolve func(Snowflake) (any, bool)
+	switch field.Type() {
+	case userType:
+		resolve = func(s Snowflake) (any, bool) { u, ok := resolved.Users[s]; return u, ok }
+	case memberType:
+		resolve = func(s Snowflake) (any, bool) {
+			m, ok := resolved.Members[s]
+			m.User = resolved.Users[s]
+			return m, ok
+		}
+	case roleType:
+		resolve = func(s Snowflake) (any, bool) { r, ok := resolved.Roles[s]; return r, ok }
+	case messageType:
+		resolve = func(s Snowflake) (any, bool) { m, ok := resolved.Messages[s]; return m, ok }
+	case channelType:
+		resolve = func(s Snowflake) (any, bool) { c, ok := resolved.Channels[s]; return c, ok }
+	case attachmentType:
+		resolve = func(s Snowflake) (any, bool) { a, ok := resolved.Attachments[s]; return a, ok }
+	case mentionableType:
+		resolve = func(s Snowflake) (any, bool) { return resolved.mentionable(s) }
+	}
+
+	if resolve == nil {
+		switch field.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			return fmt.Errorf("unsupported field type %s", field.Type())
+		}
+
+		if err := raw.UnmarshalTo(field.Addr().Interface()); err != nil {
+			return fmt.Errorf("decoding %s into %s: %w", raw, field.Type(), err)
+		}
+		return nil
+	}
+
+	var s Snowflake
+	if err := raw.UnmarshalTo(&s); err != nil {
+		return fmt.Errorf("decoding %s into %s: %w", raw, snowflakeType, err)
+	}
+
+	v, ok := resolve(s)
+	if !ok {
+		return fmt.Errorf("no %s resolved for %s", field.Type().Name(), s)
+	}
+
+	field.Set(reflect.ValueOf(v))
+	return nil
+}
@@ -0,0 +1,233 @@
+package corde_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Karitham/corde"
+	"github.com/Karitham/corde/owmock"
+	"github.com/matryer/is"
+)
+
+func TestDecodeOptions(t *testing.T) {
+	assert := is.New(t)
+
+	var data corde.SlashCommandInteractionData
+	assert.NoErr(json.Unmarshal([]byte(SampleBanCommand), &data))
+
+	var args struct {
+		Member  corde.Member    `corde:"member,required"`
+		User    corde.User      `corde:"member"`
+		ID      corde.Snowflake `corde:"member"`
+		Days    int             `corde:"days"`
+		Reason  *string         `corde:"reason"`
+		Silent  *bool           `corde:"silent"`
+		Ignored string          `corde:"-"`
+	}
+	assert.NoErr(corde.DecodeOptions(data, &args))
+
+	assert.Equal(args.Member.User.Username, "Mason")
+	assert.Equal(args.Member.Nick, "mace")
+	assert.Equal(args.User.ID, corde.Snowflake(53908232506183680))
+	assert.Equal(args.ID, corde.Snowflake(53908232506183680))
+	assert.Equal(args.Days, 7)
+	assert.Equal(*args.Reason, "spam")
+	assert.True(args.Silent == nil)
+}
+
+func TestDecodeOptionsErrors(t *testing.T) {
+	assert := is.New(t)
+
+	var data corde.SlashCommandInteractionData
+	assert.NoErr(json.Unmarshal([]byte(SampleBanCommand), &data))
+
+	var args struct {
+		Days   string     `corde:"days"`
+		Role   corde.Role `corde:"member"`
+		Silent bool       `corde:"silent,required"`
+	}
+	err := corde.DecodeOptions(data, &args)
+
+	var optsErr corde.OptionsError
+	assert.True(errors.As(err, &optsErr))
+	assert.Equal(len(optsErr), 3)
+	assert.Equal(optsErr[0].Option, "days")
+	assert.Equal(optsErr[1].Option, "member")
+	assert.True(errors.Is(optsErr[2], corde.ErrMissingOption))
+
+	assert.True(corde.DecodeOptions(data, args) != nil)
+}
+
+func TestSlashCommandTyped(t *testing.T) {
+	assert := is.New(t)
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 0, "")
+
+	type banArgs struct {
+		Member corde.Member `corde:"member,required"`
+		Days   int          `corde:"days"`
+	}
+	corde.SlashCommandTyped(mux, "ban", func(_ context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.SlashCommandInteractionData], args banArgs) {
+		w.Respond(corde.NewResp().Contentf("Banned %s for %d days", args.Member.User.Username, args.Days))
+	})
+
+	expect := &owmock.InteractionResponse{
+		Type: 4,
+		Data: corde.InteractionRespData{
+			Content: "Banned Mason for 7 days",
+		},
+	}
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, fmt.Sprintf(SampleCommandInteraction, SampleBanCommand), expect)
+	assert.NoErr(err)
+}
+
+func TestSlashCommandTypedError(t *testing.T) {
+	assert := is.New(t)
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 0, "")
+
+	onError := make(chan error, 1)
+	defaultOnError := mux.OnError
+	mux.OnError = func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.JsonRaw], err error) {
+		defaultOnError(ctx, w, i, err)
+		onError <- err
+	}
+
+	type banArgs struct {
+		Role corde.Role `corde:"role,required"`
+	}
+	corde.SlashCommandTyped(mux, "ban", func(_ context.Context, _ corde.ResponseWriter, _ *corde.Interaction[corde.SlashCommandInteractionData], _ banArgs) {
+		t.Error("handler called with invalid options")
+	})
+
+	expect := &owmock.InteractionResponse{
+		Type: 4,
+		Data: corde.InteractionRespData{
+			Content: "Invalid options: `role` is required.",
+			Flags:   corde.RESPONSE_FLAGS_EPHEMERAL,
+		},
+	}
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, fmt.Sprintf(SampleCommandInteraction, SampleBanCommand), expect)
+	assert.NoErr(err)
+
+	err = <-onError
+	var invalidErr *corde.InvalidOptionsError
+	assert.True(errors.As(err, &invalidErr))
+
+	var optsErr corde.OptionsError
+	assert.True(errors.As(err, &optsErr))
+	assert.True(errors.Is(optsErr[0], corde.ErrMissingOption))
+}
+
+// SampleCommandInteraction is an application command interaction, formatted with its data
+const SampleCommandInteraction = `{
+	"version": 1,
+	"type": 2,
+	"token": "unique_interaction_token",
+	"member": {
+		"user": {
+			"username": "Mason",
+			"id": "53908232506183680",
+			"discriminator": "1337"
+		},
+		"roles": [],
+		"permissions": "17179869183"
+	},
+	"id": "846462639134605314",
+	"guild_id": "290926798626357999",
+	"data": %s,
+	"channel_id": "345626669114982999",
+	"application_id": "290926444748734465"
+}`
+
+const SampleBanCommand = `{
+	"id": "771825006014889984",
+	"name": "ban",
+	"type": 1,
+	"options": [
+		{"name": "member", "type": 6, "value": "53908232506183680"},
+		{"name": "days", "type": 4, "value": 7},
+		{"name": "reason", "type": 3, "value": "spam"}
+	],
+	"resolved": {
+		"users": {
+			"53908232506183680": {"id": "53908232506183680", "username": "Mason", "discriminator": "1337"}
+		},
+		"members": {
+			"53908232506183680": {"nick": "mace", "roles": []}
+		}
+	}
+}`
+
+func TestDecodeOptionsAttachment(t *testing.T) {
+	assert := is.New(t)
+
+	var data corde.SlashCommandInteractionData
+	assert.NoErr(json.Unmarshal([]byte(`{
+		"name": "upload",
+		"options": [{"name": "file", "type": 11, "value": "1019624346453958656"}],
+		"resolved": {"attachments": {"1019624346453958656": {"id": "1019624346453958656", "filename": "todo.txt", "size": 42}}}
+	}`), &data))
+
+	a, err := data.OptionsAttachment("file")
+	assert.NoErr(err)
+	assert.Equal(a.Filename, "todo.txt")
+
+	var args struct {
+		File corde.Attachment `corde:"file,required"`
+	}
+	assert.NoErr(corde.DecodeOptions(data, &args))
+	assert.Equal(args.File.Size, 42)
+}
+
+func TestResolvedChannelAndMentionable(t *testing.T) {
+	assert := is.New(t)
+
+	var data corde.SlashCommandInteractionData
+	assert.NoErr(json.Unmarshal([]byte(`{
+		"name": "notify",
+		"options": [
+			{"name": "thread", "type": 7, "value": "1019624346453958657"},
+			{"name": "who", "type": 9, "value": "1019624346453958658"}
+		],
+		"resolved": {
+			"channels": {"1019624346453958657": {
+				"id": "1019624346453958657", "name": "bugs", "type": 11, "parent_id": "1019624346453958659",
+				"permissions": "3072", "thread_metadata": {"archived": false, "auto_archive_duration": 1440, "archive_timestamp": "2022-09-14T17:27:10Z", "locked": true}
+			}},
+			"roles": {"1019624346453958658": {"id": "1019624346453958658", "name": "maintainers", "permissions": "0"}}
+		}
+	}`), &data))
+
+	c, err := data.OptionsChannel("thread")
+	assert.NoErr(err)
+	assert.Equal(c.Type, corde.CHANNEL_GUILD_PUBLIC_THREAD)
+	assert.True(c.Permissions.Has(corde.PERMISSION_VIEW_CHANNEL | corde.PERMISSION_SEND_MESSAGES))
+	assert.True(c.ThreadMetadata.Locked)
+
+	m, err := data.OptionsMentionable("who")
+	assert.NoErr(err)
+	assert.True(m.User == nil)
+	assert.Equal(m.Role.Name, "maintainers")
+
+	_, err = data.OptionsChannel("who")
+	assert.True(err != nil)
+
+	var args struct {
+		Thread corde.Channel     `corde:"thread"`
+		Who    corde.Mentionable `corde:"who"`
+	}
+	assert.NoErr(corde.DecodeOptions(data, &args))
+	assert.Equal(args.Thread.ParentID, corde.Snowflake(1019624346453958659))
+	assert.Equal(args.Who.Role.ID, corde.Snowflake(1019624346453958658))
+}
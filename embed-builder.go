@@ -48,6 +48,12 @@ func (e *EmbedB) Title(s string) *EmbedB {
 	return e
 }
 
+// TitleT adds the title translated by l to the Embed
+func (e *EmbedB) TitleT(l Localizer, key string, vars map[string]any) *EmbedB {
+	e.embed.Title = l.T(key, vars)
+	return e
+}
+
 // Titlef adds the Title to the Embed
 func (e *EmbedB) Titlef(format string, a ...any) *EmbedB {
 	e.embed.Title = fmt.Sprintf(format, a...)
@@ -60,6 +66,12 @@ func (e *EmbedB) Description(s string) *EmbedB {
 	return e
 }
 
+// DescriptionT adds the description translated by l to the Embed
+func (e *EmbedB) DescriptionT(l Localizer, key string, vars map[string]any) *EmbedB {
+	e.embed.Description = l.T(key, vars)
+	return e
+}
+
 // Descriptionf adds the description to the Embed
 func (e *EmbedB) Descriptionf(format string, a ...any) *EmbedB {
 	e.embed.Description = fmt.Sprintf(format, a...)
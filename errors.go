@@ -0,0 +1,98 @@
+package corde
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// UserError is an error whose message is meant to be shown to the user.
+//
+// Any other error returned by a handler is considered internal,
+// and its message is not revealed by the default error handler.
+type UserError struct {
+	Message string
+	Err     error
+}
+
+// NewUserError returns a new UserError with the message shown to the user, wrapping the internal err
+func NewUserError(message string, err error) *UserError {
+	return &UserError{Message: message, Err: err}
+}
+
+// UserErrorf returns a new UserError with the message shown to the user
+func UserErrorf(format string, a ...any) *UserError {
+	return &UserError{Message: fmt.Sprintf(format, a...)}
+}
+
+// Error implements error
+func (e *UserError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the wrapped internal error
+func (e *UserError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultErrorMessage is the message the default error handler responds with on internal errors
+var DefaultErrorMessage = "Something went wrong, please try again later."
+
+// defaultOnError logs the error, and responds with an ephemeral message if the interaction wasn't responded to yet.
+//
+// The message of a UserError or InvalidOptionsError is shown to the user, other errors are replaced by DefaultErrorMessage.
+func defaultOnError(ctx context.Context, w ResponseWriter, i *Interaction[JsonRaw], err error) {
+	loggerFrom(ctx).ErrorContext(ctx, "handling interaction", append(interactionAttrs(i), slog.Any("error", err))...)
+
+	msg := DefaultErrorMessage
+	var userErr *UserError
+	var optsErr *InvalidOptionsError
+	switch {
+	case errors.As(err, &userErr):
+		msg = userErr.Message
+	case errors.As(err, &optsErr):
+		msg = optsErr.Message()
+	}
+
+	respondError(w, i, msg)
+}
+
+// PanicError is a panic recovered while routing an interaction
+type PanicError struct {
+	Value any    // the value passed to panic
+	Stack []byte // the stack trace of the panicking goroutine
+}
+
+// Error implements error
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// defaultOnPanic logs the panic and its stack trace,
+// and responds with DefaultErrorMessage if the interaction wasn't responded to yet
+func defaultOnPanic(ctx context.Context, w ResponseWriter, i *Interaction[JsonRaw], p *PanicError) {
+	loggerFrom(ctx).ErrorContext(ctx, "recovered panic handling interaction",
+		append(interactionAttrs(i), slog.Any("panic", p.Value), slog.String("stack", string(p.Stack)))...,
+	)
+
+	respondError(w, i, DefaultErrorMessage)
+}
+
+// respondError responds with an ephemeral message, if the interaction wasn't responded to yet.
+// Deferred interactions get the message as their original response.
+func respondError(w ResponseWriter, i *Interaction[JsonRaw], msg string) {
+	if w.Written() && !w.Deferred() {
+		return
+	}
+
+	if i.InnerInteractionType == AutocompleteInteraction {
+		w.Autocomplete(NewResp())
+		return
+	}
+
+	w.Respond(NewResp().Content(msg).Ephemeral())
+}
@@ -0,0 +1,157 @@
+package corde_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Karitham/corde"
+	"github.com/Karitham/corde/owmock"
+	"github.com/matryer/is"
+)
+
+func TestHandlerError(t *testing.T) {
+	tt := []struct {
+		Name   string
+		Err    error
+		Expect string
+	}{
+		{
+			Name:   "User Error",
+			Err:    corde.UserErrorf("page %d does not exist", 3),
+			Expect: "page 3 does not exist",
+		},
+		{
+			Name:   "Wrapped User Error",
+			Err:    corde.NewUserError("couldn't list commands", errors.New("connection reset")),
+			Expect: "couldn't list commands",
+		},
+		{
+			Name:   "Internal Error",
+			Err:    errors.New("connection reset"),
+			Expect: corde.DefaultErrorMessage,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert := is.New(t)
+			pub, _ := owmock.GenerateKeys()
+			mux := corde.NewMux(pub, 0, "")
+			mux.ButtonComponentE("click_one", func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.ButtonInteractionData]) error {
+				return tc.Err
+			})
+
+			expect := &owmock.InteractionResponse{
+				Type: 4,
+				Data: corde.InteractionRespData{
+					Content: tc.Expect,
+					Flags:   corde.RESPONSE_FLAGS_EPHEMERAL,
+				},
+			}
+
+			s := httptest.NewServer(mux)
+			defer s.Close()
+			err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleComponent, expect)
+			assert.NoErr(err)
+		})
+	}
+}
+
+func TestHandlerErrorAfterWrite(t *testing.T) {
+	assert := is.New(t)
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 0, "")
+
+	onError := make(chan error, 1)
+	defaultOnError := mux.OnError
+	mux.OnError = func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.JsonRaw], err error) {
+		defaultOnError(ctx, w, i, err)
+		onError <- err
+	}
+	mux.ButtonComponentE("click_one", func(_ context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) error {
+		w.Respond(corde.NewResp().Content("Hello World!"))
+		return errors.New("failed after responding")
+	})
+
+	expect := &owmock.InteractionResponse{
+		Type: 4,
+		Data: corde.InteractionRespData{
+			Content: "Hello World!",
+		},
+	}
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleComponent, expect)
+	assert.NoErr(err)
+	assert.Equal((<-onError).Error(), "failed after responding") // OnError is still called
+}
+
+func TestHandlerErrorAfterDefer(t *testing.T) {
+	assert := is.New(t)
+
+	edits := make(chan string, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data corde.InteractionRespData
+		json.NewDecoder(r.Body).Decode(&data)
+		edits <- r.Method + " " + r.URL.Path + " " + data.Content
+	}))
+	defer api.Close()
+
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 290926444748734465, "", corde.APIRootOpt(api.URL))
+	mux.ButtonComponentE("click_one", func(_ context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) error {
+		w.DeferedRespond()
+		return corde.UserErrorf("page %d does not exist", 3)
+	})
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleComponent, &owmock.InteractionResponse{Type: 5})
+	assert.NoErr(err)
+
+	select {
+	case e := <-edits:
+		assert.Equal(e, "PATCH /v10/webhooks/290926444748734465/unique_interaction_token/messages/@original page 3 does not exist")
+	case <-time.After(time.Second):
+		t.Fatal("expected the original response to be edited with the error")
+	}
+}
+
+func TestHandlerPanic(t *testing.T) {
+	assert := is.New(t)
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 0, "")
+
+	var recovered *corde.PanicError
+	defaultOnPanic := mux.OnPanic
+	mux.OnPanic = func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.JsonRaw], p *corde.PanicError) {
+		recovered = p
+		assert.Equal(i.Route, "click_one")
+		assert.Equal(i.ID, corde.Snowflake(846462639134605312))
+		defaultOnPanic(ctx, w, i, p)
+	}
+	mux.ButtonComponent("click_one", func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.ButtonInteractionData]) {
+		panic("oops")
+	})
+
+	expect := &owmock.InteractionResponse{
+		Type: 4,
+		Data: corde.InteractionRespData{
+			Content: corde.DefaultErrorMessage,
+			Flags:   corde.RESPONSE_FLAGS_EPHEMERAL,
+		},
+	}
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleComponent, expect)
+	assert.NoErr(err)
+	assert.Equal(recovered.Value, "oops")
+	assert.True(len(recovered.Stack) > 0)
+}
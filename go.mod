@@ -1,6 +1,6 @@
 module github.com/Karitham/corde
 
-go 1.18
+go 1.21
 
 require (
 	github.com/akrennmair/go-radix v1.0.1-0.20211215212324-49d05194b0a3
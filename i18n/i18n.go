@@ -0,0 +1,275 @@
+// Package i18n translates responses in the language of the users,
+// using catalogs of messages with plural rules and interpolation.
+//
+// Catalogs are loaded from JSON or gettext PO files named after their locale, such as `fr.json` or `pt-BR.po`.
+// JSON catalogs map message keys to their translation, or to their plural forms by CLDR category:
+//
+//	{
+//		"greeting": "Bonjour {name} !",
+//		"todos": {"one": "{count} tâche", "other": "{count} tâches"}
+//	}
+//
+// Handlers retrieve a Localizer for the locale of the interaction,
+// falling back on the locale of the guild, then on the default locale of the Translator:
+//
+//	t, _ := i18n.Load(os.DirFS("locales"), "en-US")
+//	m.Use(t.Middleware())
+//
+//	m.SlashCommand("todo/list", func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.SlashCommandInteractionData]) {
+//		l := i18n.FromContext(ctx)
+//		w.Respond(corde.NewResp().Content(l.N("todos", len(todos), nil)))
+//	})
+//
+// Response and embed builders translate their text directly, as with `corde.NewResp().ContentT(l, "greeting", i18n.Vars{"name": name})`.
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/Karitham/corde"
+)
+
+// Message is a translated message, by plural category.
+// Messages without plural forms only have the Other category.
+type Message map[string]string
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a string or plural forms
+func (m *Message) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*m = Message{Other: s}
+		return nil
+	}
+
+	var forms map[string]string
+	if err := json.Unmarshal(b, &forms); err != nil {
+		return err
+	}
+	*m = forms
+	return nil
+}
+
+// Catalog is the set of messages of a locale, by key
+type Catalog map[string]Message
+
+// Vars are the values interpolated in messages, replacing their `{name}` placeholders
+type Vars = map[string]any
+
+// Translator holds the catalogs of every locale
+type Translator struct {
+	catalogs      map[string]Catalog
+	defaultLocale string
+}
+
+// New returns a Translator without catalogs, falling back on the default locale
+func New(defaultLocale string) *Translator {
+	return &Translator{
+		catalogs:      map[string]Catalog{},
+		defaultLocale: defaultLocale,
+	}
+}
+
+// Load returns a Translator with the catalogs found at the root of fsys.
+//
+// Files ending in `.json` and `.po` are loaded as catalogs, the locale being their name without extension.
+func Load(fsys fs.FS, defaultLocale string) (*Translator, error) {
+	t := New(defaultLocale)
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		ext := path.Ext(e.Name())
+		if e.IsDir() || (ext != ".json" && ext != ".po") {
+			continue
+		}
+
+		locale := strings.TrimSuffix(e.Name(), ext)
+		if err := t.loadFile(fsys, e.Name(), locale, ext); err != nil {
+			return nil, fmt.Errorf("i18n: loading %s: %w", e.Name(), err)
+		}
+	}
+
+	return t, nil
+}
+
+func (t *Translator) loadFile(fsys fs.FS, name string, locale string, ext string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var c Catalog
+	switch ext {
+	case ".json":
+		err = json.NewDecoder(f).Decode(&c)
+	case ".po":
+		c, err = ParsePO(locale, f)
+	}
+	if err != nil {
+		return err
+	}
+
+	t.Add(locale, c)
+	return nil
+}
+
+// Add adds the messages of the catalog to the locale, overriding existing ones
+func (t *Translator) Add(locale string, c Catalog) {
+	existing, ok := t.catalogs[locale]
+	if !ok {
+		existing = Catalog{}
+		t.catalogs[locale] = existing
+	}
+
+	for k, m := range c {
+		existing[k] = m
+	}
+}
+
+// Localizer returns a Localizer for the locales, in order of preference.
+//
+// Each locale falls back on its language, such as `pt` for `pt-BR`,
+// and the default locale of the translator is tried last.
+func (t *Translator) Localizer(locales ...string) *Localizer {
+	l := &Localizer{}
+	seen := map[string]bool{}
+	for _, locale := range append(append([]string{}, locales...), t.defaultLocale) {
+		for _, candidate := range []string{locale, language(locale)} {
+			c, ok := t.catalogs[candidate]
+			if candidate == "" || seen[candidate] || !ok {
+				continue
+			}
+
+			seen[candidate] = true
+			l.chain = append(l.chain, localeCatalog{locale: candidate, catalog: c})
+		}
+	}
+
+	return l
+}
+
+// For returns a Localizer for the locale of the user of the interaction,
+// falling back on the locale of its guild
+func For[T corde.InteractionDataConstraint](t *Translator, i *corde.Interaction[T]) *Localizer {
+	return t.Localizer(i.Locale, i.GuildLocale)
+}
+
+// localizerKey is the context key holding the Localizer of the interaction
+type localizerKey struct{}
+
+// Middleware returns a middleware making the Localizer of each interaction available with FromContext
+func (t *Translator) Middleware() corde.Middleware {
+	return func(next corde.HandlerFunc) corde.HandlerFunc {
+		return func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.JsonRaw]) {
+			next(context.WithValue(ctx, localizerKey{}, For(t, i)), w, i)
+		}
+	}
+}
+
+// FromContext returns the Localizer set by the Middleware of a Translator.
+// Without it, it returns a Localizer without catalogs, which returns message keys as is.
+func FromContext(ctx context.Context) *Localizer {
+	if l, ok := ctx.Value(localizerKey{}).(*Localizer); ok {
+		return l
+	}
+	return &Localizer{}
+}
+
+var _ corde.Localizer = (*Localizer)(nil)
+
+// Localizer translates messages using a chain of catalogs
+type Localizer struct {
+	chain []localeCatalog
+}
+
+type localeCatalog struct {
+	locale  string
+	catalog Catalog
+}
+
+// Locale returns the locale of the preferred catalog, or an empty string if there is none
+func (l *Localizer) Locale() string {
+	if len(l.chain) == 0 {
+		return ""
+	}
+	return l.chain[0].locale
+}
+
+// T returns the message translated in the first locale of the chain having it,
+// with its placeholders replaced by vars.
+// It returns the key if no locale has the message.
+func (l *Localizer) T(key string, vars Vars) string {
+	for _, c := range l.chain {
+		if s, ok := c.catalog[key][Other]; ok {
+			return interpolate(s, vars)
+		}
+	}
+	return key
+}
+
+// N returns the plural form of the message for count,
+// translated in the first locale of the chain having it.
+// The `{count}` placeholder is replaced by count, and the others by vars.
+// It returns the key if no locale has the message.
+func (l *Localizer) N(key string, count int, vars Vars) string {
+	withCount := Vars{"count": count}
+	for k, v := range vars {
+		withCount[k] = v
+	}
+
+	for _, c := range l.chain {
+		m, ok := c.catalog[key]
+		if !ok {
+			continue
+		}
+
+		if s, ok := m[RuleFor(c.locale).Select(count)]; ok {
+			return interpolate(s, withCount)
+		}
+		if s, ok := m[Other]; ok {
+			return interpolate(s, withCount)
+		}
+	}
+
+	return key
+}
+
+// interpolate replaces the `{name}` placeholders of s with vars, leaving unknown ones as is
+func interpolate(s string, vars Vars) string {
+	if len(vars) == 0 || !strings.Contains(s, "{") {
+		return s
+	}
+
+	b := &strings.Builder{}
+	for {
+		start := strings.IndexByte(s, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b.WriteString(s[:start])
+		if v, ok := vars[s[start+1:end]]; ok {
+			fmt.Fprint(b, v)
+		} else {
+			b.WriteString(s[start : end+1])
+		}
+		s = s[end+1:]
+	}
+	b.WriteString(s)
+
+	return b.String()
+}
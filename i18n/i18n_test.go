@@ -0,0 +1,171 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/Karitham/corde"
+	"github.com/matryer/is"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func loadTestdata(t *testing.T) *Translator {
+	t.Helper()
+
+	fsys, err := fs.Sub(testdata, "testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr, err := Load(fsys, "en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tr
+}
+
+func TestLocalizer(t *testing.T) {
+	tr := loadTestdata(t)
+
+	tt := []struct {
+		Name    string
+		Locales []string
+		Got     func(*Localizer) string
+		Expect  string
+	}{
+		{
+			Name:    "Interpolation",
+			Locales: []string{"fr"},
+			Got:     func(l *Localizer) string { return l.T("greeting", Vars{"name": "Mason"}) },
+			Expect:  "Bonjour Mason !",
+		},
+		{
+			Name:    "Unknown placeholder",
+			Locales: []string{"en-US"},
+			Got:     func(l *Localizer) string { return l.T("greeting", Vars{"nick": "Mason"}) },
+			Expect:  "Hello {name}!",
+		},
+		{
+			Name:    "French zero is singular",
+			Locales: []string{"fr"},
+			Got:     func(l *Localizer) string { return l.N("todos", 0, nil) },
+			Expect:  "0 tâche",
+		},
+		{
+			Name:    "English zero is plural",
+			Locales: []string{"en-US"},
+			Got:     func(l *Localizer) string { return l.N("todos", 0, nil) },
+			Expect:  "0 todos",
+		},
+		{
+			Name:    "Japanese has no plural",
+			Locales: []string{"ja"},
+			Got:     func(l *Localizer) string { return l.N("todos", 3, nil) },
+			Expect:  "3件のタスク",
+		},
+		{
+			Name:    "Russian few",
+			Locales: []string{"ru"},
+			Got:     func(l *Localizer) string { return l.N("todos", 22, nil) },
+			Expect:  "22 задачи",
+		},
+		{
+			Name:    "Russian many",
+			Locales: []string{"ru"},
+			Got:     func(l *Localizer) string { return l.N("todos", 12, nil) },
+			Expect:  "12 задач",
+		},
+		{
+			Name:    "Untranslated PO entry falls back",
+			Locales: []string{"ru"},
+			Got:     func(l *Localizer) string { return l.T("help", nil) },
+			Expect:  "Use /todo add to add a todo.",
+		},
+		{
+			Name:    "Language fallback",
+			Locales: []string{"fr-CA"},
+			Got:     func(l *Localizer) string { return l.T("greeting", Vars{"name": "Mason"}) },
+			Expect:  "Bonjour Mason !",
+		},
+		{
+			Name:    "Guild locale fallback",
+			Locales: []string{"de", "ja"},
+			Got:     func(l *Localizer) string { return l.T("greeting", Vars{"name": "Mason"}) },
+			Expect:  "こんにちは、Masonさん！",
+		},
+		{
+			Name:    "Missing key",
+			Locales: []string{"fr"},
+			Got:     func(l *Localizer) string { return l.T("missing", nil) },
+			Expect:  "missing",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert := is.New(t)
+			assert.Equal(tc.Got(tr.Localizer(tc.Locales...)), tc.Expect)
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	assert := is.New(t)
+	tr := loadTestdata(t)
+
+	var got string
+	h := tr.Middleware()(func(ctx context.Context, _ corde.ResponseWriter, _ *corde.Interaction[corde.JsonRaw]) {
+		got = FromContext(ctx).T("greeting", Vars{"name": "Mason"})
+	})
+	h(context.Background(), nil, &corde.Interaction[corde.JsonRaw]{Locale: "es-ES", GuildLocale: "fr"})
+
+	assert.Equal(got, "Bonjour Mason !")
+	assert.Equal(FromContext(context.Background()).T("greeting", nil), "greeting")
+}
+
+func TestParsePOErrors(t *testing.T) {
+	assert := is.New(t)
+
+	_, err := ParsePO("en-US", strings.NewReader("msgid \"a\"\nmsgstr[0] \"a\"\nmsgstr[1] \"b\"\nmsgstr[2] \"c\"\nmsgid_plural \"a\"\n"))
+	assert.True(err != nil)
+
+	_, err = ParsePO("en-US", strings.NewReader("msgfoo \"a\"\n"))
+	assert.True(err != nil)
+}
+
+func TestBuilders(t *testing.T) {
+	assert := is.New(t)
+	l := loadTestdata(t).Localizer("fr")
+
+	resp := corde.NewResp().ContentT(l, "greeting", Vars{"name": "Léa"}).InteractionRespData()
+	assert.Equal(resp.Content, "Bonjour Léa !")
+
+	embed := corde.NewEmbed().TitleT(l, "greeting", Vars{"name": "Léa"}).DescriptionT(l, "help", nil).Embed()
+	assert.Equal(embed.Title, "Bonjour Léa !")
+	assert.Equal(embed.Description, "Use /todo add to add a todo.")
+}
+
+func TestParsePOFuzzy(t *testing.T) {
+	assert := is.New(t)
+
+	c, err := ParsePO("fr", strings.NewReader(`#, fuzzy
+msgid ""
+msgstr "Language: fr\n"
+
+#, fuzzy, c-format
+msgid "greeting"
+msgstr "Salut {name}"
+
+msgid "help"
+msgstr "Aide"
+`))
+	assert.NoErr(err)
+	_, ok := c["greeting"]
+	assert.True(!ok) // fuzzy entries are skipped
+	assert.Equal(c["help"][Other], "Aide")
+}
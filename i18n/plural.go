@@ -0,0 +1,131 @@
+package i18n
+
+import "strings"
+
+// Plural categories, as defined by CLDR
+// https://cldr.unicode.org/index/cldr-spec/plural-rules
+const (
+	Zero  = "zero"
+	One   = "one"
+	Two   = "two"
+	Few   = "few"
+	Many  = "many"
+	Other = "other"
+)
+
+// PluralRule selects the plural category of a count in a language
+type PluralRule struct {
+	// Categories are the categories used by the language, in the order of gettext plural forms
+	Categories []string
+	// Select returns the category of n
+	Select func(n int) string
+}
+
+var (
+	ruleOneOther = PluralRule{
+		Categories: []string{One, Other},
+		Select: func(n int) string {
+			if n == 1 {
+				return One
+			}
+			return Other
+		},
+	}
+
+	// ruleZeroOne is for languages treating 0 as singular, such as french
+	ruleZeroOne = PluralRule{
+		Categories: []string{One, Other},
+		Select: func(n int) string {
+			if n == 0 || n == 1 {
+				return One
+			}
+			return Other
+		},
+	}
+
+	ruleOther = PluralRule{
+		Categories: []string{Other},
+		Select:     func(int) string { return Other },
+	}
+
+	// ruleSlavic is for east slavic languages, such as russian
+	ruleSlavic = PluralRule{
+		Categories: []string{One, Few, Many},
+		Select: func(n int) string {
+			switch mod10, mod100 := n%10, n%100; {
+			case mod10 == 1 && mod100 != 11:
+				return One
+			case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+				return Few
+			default:
+				return Many
+			}
+		},
+	}
+
+	rulePolish = PluralRule{
+		Categories: []string{One, Few, Many},
+		Select: func(n int) string {
+			switch mod10, mod100 := n%10, n%100; {
+			case n == 1:
+				return One
+			case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+				return Few
+			default:
+				return Many
+			}
+		},
+	}
+
+	// ruleCzech is for czech and slovak
+	ruleCzech = PluralRule{
+		Categories: []string{One, Few, Other},
+		Select: func(n int) string {
+			switch {
+			case n == 1:
+				return One
+			case n >= 2 && n <= 4:
+				return Few
+			default:
+				return Other
+			}
+		},
+	}
+)
+
+// pluralRules are the plural rules of the languages discord supports
+var pluralRules = map[string]PluralRule{
+	"fr":    ruleZeroOne,
+	"pt-BR": ruleZeroOne,
+	"hi":    ruleZeroOne,
+	"ja":    ruleOther,
+	"ko":    ruleOther,
+	"zh":    ruleOther,
+	"th":    ruleOther,
+	"vi":    ruleOther,
+	"id":    ruleOther,
+	"ru":    ruleSlavic,
+	"uk":    ruleSlavic,
+	"hr":    ruleSlavic,
+	"pl":    rulePolish,
+	"cs":    ruleCzech,
+	"sk":    ruleCzech,
+}
+
+// RuleFor returns the plural rule of the locale,
+// falling back on its language, then on the english rule
+func RuleFor(locale string) PluralRule {
+	if r, ok := pluralRules[locale]; ok {
+		return r
+	}
+	if r, ok := pluralRules[language(locale)]; ok {
+		return r
+	}
+	return ruleOneOther
+}
+
+// language returns the language of the locale, such as `pt` for `pt-BR`
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParsePO parses a gettext PO catalog for the locale.
+//
+// Plural forms, in `msgstr[N]`, are mapped to the categories of the plural rule of the locale,
+// in order. Untranslated and fuzzy entries are skipped, as gettext does, and contexts, other flags and comments are ignored.
+func ParsePO(locale string, r io.Reader) (Catalog, error) {
+	rule := RuleFor(locale)
+	c := Catalog{}
+
+	var (
+		id, plural       string
+		forms            = map[int]*string{}
+		last             *string // the string continuation lines are appended to
+		fuzzy, nextFuzzy bool    // the entry, or the next one, is flagged fuzzy
+		lineNo           int
+	)
+
+	flush := func() error {
+		defer func() {
+			id, plural, forms, last = "", "", map[int]*string{}, nil
+		}()
+
+		if id == "" || fuzzy {
+			return nil // the header, no entry yet, or a fuzzy entry
+		}
+
+		m := Message{}
+		for i, s := range forms {
+			if *s == "" {
+				continue
+			}
+			if plural == "" {
+				m[Other] = *s
+				continue
+			}
+			if i >= len(rule.Categories) {
+				return fmt.Errorf("msgstr[%d] of %q has no plural category in %s", i, id, locale)
+			}
+			m[rule.Categories[i]] = *s
+		}
+
+		if len(m) > 0 {
+			c[id] = m
+		}
+		return nil
+	}
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		lineNo++
+		line := strings.TrimSpace(s.Text())
+
+		if strings.HasPrefix(line, "#,") && strings.Contains(line, "fuzzy") {
+			nextFuzzy = true
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, `"`) {
+			if last == nil {
+				return nil, fmt.Errorf("line %d: unexpected string", lineNo)
+			}
+			str, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			*last += str
+			continue
+		}
+
+		keyword, value, _ := strings.Cut(line, " ")
+		str, err := strconv.Unquote(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		switch {
+		case keyword == "msgctxt":
+			last = &str
+		case keyword == "msgid":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			id, fuzzy, nextFuzzy = str, nextFuzzy, false
+			last = &id
+		case keyword == "msgid_plural":
+			plural = str
+			last = &plural
+		case keyword == "msgstr":
+			forms[0] = &str
+			last = &str
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+			i, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			forms[i] = &str
+			last = &str
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword %q", lineNo, keyword)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
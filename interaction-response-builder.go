@@ -15,6 +15,11 @@ func NewResp() *RespB {
 	return &RespB{resp: &InteractionRespData{}}
 }
 
+// Localizer translates messages by key, replacing their placeholders with vars, such as the i18n Localizer
+type Localizer interface {
+	T(key string, vars map[string]any) string
+}
+
 // Embedder returns an Embed
 type Embedder interface {
 	Embed() Embed
@@ -29,6 +34,12 @@ func (r *RespB) Content(s string) *RespB {
 	return r
 }
 
+// ContentT adds the content translated by l to the InteractionRespData
+func (r *RespB) ContentT(l Localizer, key string, vars map[string]any) *RespB {
+	r.resp.Content = l.T(key, vars)
+	return r
+}
+
 // Contentf adds the content to the InteractionRespData
 func (r *RespB) Contentf(s string, args ...any) *RespB {
 	r.resp.Content = fmt.Sprintf(s, args...)
@@ -2,6 +2,7 @@ package corde
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -60,9 +61,9 @@ func writeAttachments(mw *multipart.Writer, attachments []Attachment) error {
 // GetOriginalInteraction returns the original response to an Interaction
 //
 // https://discord.com/developers/docs/interactions/receiving-and-responding#get-original-interaction-response
-func (m *Mux) GetOriginalInteraction(token string) (*InteractionRespData, error) {
+func (m *Mux) GetOriginalInteraction(ctx context.Context, token string) (*InteractionRespData, error) {
 	data := &InteractionRespData{}
-	_, err := rest.DoJSON(m.Client, rest.Req("/webhooks", m.AppID, token, "messages/@original").Get(m.authorize), data)
+	err := m.do(m.api.Req(ctx, "/webhooks", m.AppID, token, "messages/@original").Get(m.authorize), data)
 	if err != nil {
 		return nil, err
 	}
@@ -73,64 +74,54 @@ func (m *Mux) GetOriginalInteraction(token string) (*InteractionRespData, error)
 // EditOriginalInteraction to edit your initial response to an Interaction
 //
 // https://discord.com/developers/docs/interactions/receiving-and-responding#edit-original-interaction-response
-func (m *Mux) EditOriginalInteraction(token string, data InteractionResponder) error {
+func (m *Mux) EditOriginalInteraction(ctx context.Context, token string, data InteractionResponder) error {
 	body := &bytes.Buffer{}
 	contentType, err := toBody(body, data.InteractionRespData())
 	if err != nil {
 		return err
 	}
 
-	_, err = m.Client.Do(
-		rest.Req("/webhooks", m.AppID, token, "messages/@original").
+	return m.do(
+		m.api.Req(ctx, "/webhooks", m.AppID, token, "messages/@original").
 			AnyBody(body).Patch(m.authorize, rest.ContentType(contentType)),
+		nil,
 	)
-	if err != nil {
-		return err
-	}
-	return nil
 }
 
 // DeleteOriginalInteraction to delete your initial response to an Interaction
 //
 // https://discord.com/developers/docs/interactions/receiving-and-responding#edit-original-interaction-response
-func (m *Mux) DeleteOriginalInteraction(token string) error {
-	_, err := m.Client.Do(
-		rest.Req("/webhooks", m.AppID, token, "messages/@original").
+func (m *Mux) DeleteOriginalInteraction(ctx context.Context, token string) error {
+	return m.do(
+		m.api.Req(ctx, "/webhooks", m.AppID, token, "messages/@original").
 			Delete(m.authorize),
+		nil,
 	)
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
 
 // FollowUpInteraction follows up a response to an Interaction
 //
 // https://discord.com/developers/docs/interactions/receiving-and-responding#followup-messages
-func (m *Mux) FollowUpInteraction(token string, data InteractionResponder) error {
+func (m *Mux) FollowUpInteraction(ctx context.Context, token string, data InteractionResponder) error {
 	body := &bytes.Buffer{}
 	contentType, err := toBody(body, data.InteractionRespData())
 	if err != nil {
 		return err
 	}
 
-	_, err = m.Client.Do(
-		rest.Req("/webhooks", m.AppID, token).
+	return m.do(
+		m.api.Req(ctx, "/webhooks", m.AppID, token).
 			AnyBody(body).Post(m.authorize, rest.ContentType(contentType)),
+		nil,
 	)
-	if err != nil {
-		return err
-	}
-	return nil
 }
 
 // GetFollowUpInteraction returns the response to a FollowUpInteraction
 //
 // https://discord.com/developers/docs/interactions/receiving-and-responding#get-followup-message
-func (m *Mux) GetFollowUpInteraction(token string, messageID Snowflake) (*InteractionRespData, error) {
+func (m *Mux) GetFollowUpInteraction(ctx context.Context, token string, messageID Snowflake) (*InteractionRespData, error) {
 	data := &InteractionRespData{}
-	_, err := rest.DoJSON(m.Client, rest.Req("/webhooks", m.AppID, token, "messages", messageID).Get(m.authorize), data)
+	err := m.do(m.api.Req(ctx, "/webhooks", m.AppID, token, "messages", messageID).Get(m.authorize), data)
 	if err != nil {
 		return nil, err
 	}
@@ -141,34 +132,27 @@ func (m *Mux) GetFollowUpInteraction(token string, messageID Snowflake) (*Intera
 // EditFollowUpInteraction to edit a response to a FollowUpInteraction
 //
 // https://discord.com/developers/docs/interactions/receiving-and-responding#edit-followup-message
-func (m *Mux) EditFollowUpInteraction(token string, messageID Snowflake, data InteractionResponder) error {
+func (m *Mux) EditFollowUpInteraction(ctx context.Context, token string, messageID Snowflake, data InteractionResponder) error {
 	body := &bytes.Buffer{}
 	contentType, err := toBody(body, data.InteractionRespData())
 	if err != nil {
 		return err
 	}
 
-	_, err = m.Client.Do(
-		rest.Req("/webhooks", m.AppID, token, "messages", messageID).
+	return m.do(
+		m.api.Req(ctx, "/webhooks", m.AppID, token, "messages", messageID).
 			AnyBody(body).Patch(m.authorize, rest.ContentType(contentType)),
+		nil,
 	)
-	if err != nil {
-		return err
-	}
-	return nil
 }
 
 // DeleteFollowUpInteraction to delete a response to a FollowUpInteraction
 //
 // https://discord.com/developers/docs/interactions/receiving-and-responding#delete-followup-message
-func (m *Mux) DeleteFollowUpInteraction(token string, messageID Snowflake) error {
-	_, err := m.Client.Do(
-		rest.Req("/webhooks", m.AppID, token, "messages", messageID).
+func (m *Mux) DeleteFollowUpInteraction(ctx context.Context, token string, messageID Snowflake) error {
+	return m.do(
+		m.api.Req(ctx, "/webhooks", m.AppID, token, "messages", messageID).
 			Delete(m.authorize),
+		nil,
 	)
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
@@ -45,6 +45,31 @@ const (
 	ModalInteraction
 )
 
+// String implements fmt.Stringer
+func (t InnerInteractionType) String() string {
+	switch t {
+	case ActionRowInteraction:
+		return "action_row"
+	case ButtonInteraction:
+		return "button"
+	case SelectMenuInteraction:
+		return "select_menu"
+	case TextInputInteraction:
+		return "text_input"
+	case AutocompleteInteraction:
+		return "autocomplete"
+	case SlashCommandInteraction:
+		return "slash_command"
+	case UserCommandInteraction:
+		return "user_command"
+	case MessageCommandInteraction:
+		return "message_command"
+	case ModalInteraction:
+		return "modal"
+	}
+	return "unknown"
+}
+
 // Interaction is a Discord Interaction
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interactions
 type Interaction[T InteractionDataConstraint] struct {
@@ -66,6 +91,15 @@ type Interaction[T InteractionDataConstraint] struct {
 	InnerInteractionType InnerInteractionType `json:"-"`
 }
 
+// user returns the user who triggered the interaction,
+// which is the member's user in guilds
+func (i *Interaction[T]) user() User {
+	if i.User != nil {
+		return *i.User
+	}
+	return i.Member.User
+}
+
 type (
 	_basicT struct {
 		Type InteractionType `json:"type"`
@@ -118,10 +152,15 @@ type (
 		ComponentType ComponentType `json:"component_type"`
 	}
 
+	// SelectInteractionData is the data of any select menu.
+	//
+	// Values holds the selected option values for string selects,
+	// and the IDs of the selected entities for the other selects, which are resolved in Resolved.
 	SelectInteractionData struct {
 		CustomID      string        `json:"custom_id,omitempty"`
 		ComponentType ComponentType `json:"component_type"`
-		Values        []any         `json:"values,omitempty"`
+		Values        []string      `json:"values,omitempty"`
+		Resolved      Resolved      `json:"resolved,omitempty"`
 	}
 
 	TextInputInteractionData struct {
@@ -171,11 +210,11 @@ type (
 	}
 
 	PartialRoutingType struct {
-		ID            Snowflake `json:"id"`
-		Type          int       `json:"type"`
-		ComponentType int       `json:"component_type"`
-		Name          string    `json:"name"`
-		CustomID      string    `json:"custom_id"`
+		ID            Snowflake     `json:"id"`
+		Type          int           `json:"type"`
+		ComponentType ComponentType `json:"component_type"`
+		Name          string        `json:"name"`
+		CustomID      string        `json:"custom_id"`
 		resolvedInteractionWithOptions
 	}
 )
@@ -10,14 +10,15 @@ import (
 )
 
 var types = []options{
-	{DiscordType: "OPTION_STRING", Type: "string", Name: "string", CanAutocomplete: true},
-	{DiscordType: "OPTION_INTEGER", Type: "int", Name: "int", CanAutocomplete: true},
-	{DiscordType: "OPTION_NUMBER", Type: "float64", Name: "number", CanAutocomplete: true},
+	{DiscordType: "OPTION_STRING", Type: "string", Name: "string", CanAutocomplete: true, HasLength: true},
+	{DiscordType: "OPTION_INTEGER", Type: "int", Name: "int", CanAutocomplete: true, HasRange: true},
+	{DiscordType: "OPTION_NUMBER", Type: "float64", Name: "number", CanAutocomplete: true, HasRange: true},
 	{DiscordType: "OPTION_BOOLEAN", Type: "bool", Name: "bool"},
 	{DiscordType: "OPTION_USER", Type: "Snowflake", Name: "user"},
 	{DiscordType: "OPTION_CHANNEL", Type: "Snowflake", Name: "channel"},
 	{DiscordType: "OPTION_ROLE", Type: "Snowflake", Name: "role"},
 	{DiscordType: "OPTION_MENTIONABLE", Type: "Snowflake", Name: "mentionable"},
+	{DiscordType: "OPTION_ATTACHMENT", Type: "Snowflake", Name: "attachment"},
 }
 
 type options struct {
@@ -25,6 +26,8 @@ type options struct {
 	Type            string
 	Name            string
 	CanAutocomplete bool
+	HasRange        bool // min_value and max_value
+	HasLength       bool // min_length and max_length
 }
 
 var ConstructorBody string = `	o := &%s{
@@ -38,7 +41,7 @@ var ConstructorBody string = `	o := &%s{
 	for _, choice := range choices {
 		o.Choices = append(
 			o.Choices,
-			Choice[any]{Name: choice.Name, Value: choice.Value},
+			Choice[any]{Name: choice.Name, Value: choice.Value, NameLocalizations: choice.NameLocalizations},
 		)
 	}
 
@@ -52,10 +55,20 @@ var CreateOptionBody string = `	return CreateOption{
 		Choices:     o.Choices,
 		ChannelTypes: o.ChannelTypes,
 		Autocomplete: o.Autocomplete,
-		Type:         %s,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+%s		Type:         %s,
 	}
 `
 
+var RangeFields = `		MinValue: o.MinValue,
+		MaxValue: o.MaxValue,
+`
+
+var LengthFields = `		MinLength: o.MinLength,
+		MaxLength: o.MaxLength,
+`
+
 var file = flag.String("file", "../../../register-cmd.gen.go", "output file")
 
 //go:generate go run .
@@ -78,7 +91,19 @@ func main() {
 			Field("Required", "bool").
 			Field("Choices", "[]Choice[any]").
 			Field("ChannelTypes", "[]ChannelType").
-			Field("Autocomplete", "bool")
+			Field("Autocomplete", "bool").
+			Field("NameLocalizations", "Localizations").
+			Field("DescriptionLocalizations", "Localizations")
+
+		constraintFields := ""
+		if v.HasRange {
+			typeOpt.Field("MinValue", "*float64").Field("MaxValue", "*float64")
+			constraintFields = RangeFields
+		}
+		if v.HasLength {
+			typeOpt.Field("MinLength", "*int").Field("MaxLength", "*int")
+			constraintFields = LengthFields
+		}
 
 		constructorF := &genial.FuncB{}
 		constructorF.Namef("New%s", typeName).
@@ -103,7 +128,7 @@ func main() {
 			Comment("createOption returns the CreateOption of the type").
 			Receiver("o", "*"+typeName).
 			ReturnTypes("CreateOption").
-			Writef(CreateOptionBody, v.DiscordType)
+			Writef(CreateOptionBody, constraintFields, v.DiscordType)
 
 		marshalF := &genial.FuncB{}
 		marshalF.Name("MarshalJSON").
@@ -112,7 +137,17 @@ func main() {
 			ReturnTypes("[]byte", "error").
 			WriteString("\treturn json.Marshal(o.createOption())\n")
 
-		p.Declarations(typeOpt, constructorF, createOptionF, channelTypesF, marshalF)
+		localizeF := &genial.FuncB{}
+		localizeF.Name("Localize").
+			Comment("Localize sets the name and description of the option in the locale").
+			Receiver("o", "*"+typeName).
+			ReturnTypes("*"+typeName).
+			Parameter("locale", "string").
+			Parameter("name", "string").
+			Parameter("description", "string").
+			WriteString("\to.NameLocalizations = o.NameLocalizations.with(locale, name)\n\to.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)\n\treturn o\n")
+
+		p.Declarations(typeOpt, constructorF, createOptionF, channelTypesF, localizeF, marshalF)
 
 		if v.CanAutocomplete {
 			autocompleteF := &genial.FuncB{}
@@ -125,6 +160,39 @@ func main() {
 			p.Declarations(autocompleteF)
 		}
 
+		if v.HasRange {
+			minF := &genial.FuncB{}
+			minF.Name("Min").
+				Comment("Min sets the minimum value of the option").
+				Receiver("o", "*"+typeName).
+				Parameter("min", v.Type).
+				ReturnTypes("*" + typeName).
+				WriteString("\tv := float64(min)\n\to.MinValue = &v\n\treturn o\n")
+
+			maxF := &genial.FuncB{}
+			maxF.Name("Max").
+				Comment("Max sets the maximum value of the option").
+				Receiver("o", "*"+typeName).
+				Parameter("max", v.Type).
+				ReturnTypes("*" + typeName).
+				WriteString("\tv := float64(max)\n\to.MaxValue = &v\n\treturn o\n")
+
+			p.Declarations(minF, maxF)
+		}
+
+		if v.HasLength {
+			lengthF := &genial.FuncB{}
+			lengthF.Name("Length").
+				Comment("Length sets the minimum and maximum length of the option").
+				Receiver("o", "*"+typeName).
+				Parameter("min", "int").
+				Parameter("max", "int").
+				ReturnTypes("*" + typeName).
+				WriteString("\to.MinLength = &min\n\to.MaxLength = &max\n\treturn o\n")
+
+			p.Declarations(lengthF)
+		}
+
 	}
 
 	os.WriteFile(*file, p.Bytes(), 0o644)
@@ -7,7 +7,7 @@ import (
 
 // DoJSON executes a request and decodes the response into the given interface
 // It already calls `Close()` on the body
-func DoJSON(c *http.Client, r *http.Request, v any) (*http.Response, error) {
+func DoJSON(c Doer, r *http.Request, v any) (*http.Response, error) {
 	resp, err := c.Do(r)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,23 @@
+package rest
+
+import "time"
+
+// Observer is notified of the requests sent and of the rate limits waited for,
+// by their templated endpoint, such as `PATCH /webhooks/{id}/{token}/messages/@original`.
+// Its nil functions are ignored.
+type Observer struct {
+	OnRequest   func(method string, route string, status int, retries int, latency time.Duration)
+	OnRateLimit func(route string, wait time.Duration, global bool)
+}
+
+func (o Observer) request(method string, route string, status int, retries int, latency time.Duration) {
+	if o.OnRequest != nil {
+		o.OnRequest(method, route, status, retries, latency)
+	}
+}
+
+func (o Observer) rateLimit(route string, wait time.Duration, global bool) {
+	if o.OnRateLimit != nil && wait > 0 {
+		o.OnRateLimit(route, wait, global)
+	}
+}
@@ -0,0 +1,373 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Doer executes HTTP requests, as *http.Client does
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// maxRateLimitRetries is the number of times a rate limited request is retried before its 429 response is returned
+const maxRateLimitRetries = 5
+
+// sweepInterval is how often buckets which reset and aren't in use are evicted
+const sweepInterval = time.Minute
+
+// RateLimiter queues requests according to the rate limits of discord.
+//
+// It tracks the per-route buckets reported by the `X-RateLimit-*` headers, as well as the global rate limit,
+// waits for them to reset before sending requests, and retries requests answered with 429 after their `Retry-After`.
+// Buckets are forgotten once they reset and no request uses them.
+//
+// https://discord.com/developers/docs/topics/rate-limits
+type RateLimiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket // buckets by route, or by bucket hash and major parameters once known
+	hashes      map[string]string  // bucket keys by route
+	globalReset time.Time
+	swept       time.Time // when buckets were last evicted
+}
+
+// bucket is a rate limit bucket, requests reserve one of its remaining requests before being sent
+type bucket struct {
+	mu        sync.Mutex
+	remaining int
+	limit     int // the requests allowed until the reset, 0 if unknown
+	reset     time.Time
+	inflight  int           // requests sent and not answered yet
+	answered  chan struct{} // closed when a request is answered, nil if no request waits for it
+	users     int           // requests using the bucket, guarded by the mutex of the RateLimiter
+}
+
+// NewRateLimiter returns a RateLimiter without known buckets
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		buckets: map[string]*bucket{},
+		hashes:  map[string]string{},
+	}
+}
+
+// Client returns a Doer sending requests with c, according to the rate limits,
+// and reporting the waits for them to the observer
+func (l *RateLimiter) Client(c *http.Client, observer Observer) Doer {
+	return limitedClient{limiter: l, client: c, observer: observer}
+}
+
+type limitedClient struct {
+	limiter  *RateLimiter
+	client   *http.Client
+	observer Observer
+}
+
+func (c limitedClient) Do(r *http.Request) (*http.Response, error) {
+	return c.limiter.do(c.client, r, c.observer)
+}
+
+// Do sends the request with c once its bucket and the global rate limit allow it.
+//
+// Requests answered with 429 are retried after the time given by discord, as long as their body can be replayed.
+// It returns early if the context of the request is done while waiting.
+func (l *RateLimiter) Do(c *http.Client, r *http.Request) (*http.Response, error) {
+	return l.do(c, r, Observer{})
+}
+
+func (l *RateLimiter) do(c *http.Client, r *http.Request, o Observer) (*http.Response, error) {
+	route, major := routeKey(r.Method, r.URL.Path)
+	b := l.bucket(route)
+	defer l.release(b)
+
+	for attempt := 0; ; attempt++ {
+		if err := b.reserve(r.Context(), endpoint(r.Method, r.URL.Path), o); err != nil {
+			return nil, err
+		}
+
+		wait := l.untilGlobalReset()
+		o.rateLimit(endpoint(r.Method, r.URL.Path), wait, true)
+		if err := sleep(r.Context(), wait); err != nil {
+			b.cancel()
+			return nil, err
+		}
+
+		resp, err := c.Do(r)
+		if err != nil {
+			b.cancel()
+			return nil, err
+		}
+
+		l.update(b, route, major, resp)
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRateLimitRetries {
+			return resp, nil
+		}
+
+		retryAfter := parseRetryAfter(resp)
+		if isGlobal(resp) {
+			l.mu.Lock()
+			l.globalReset = time.Now().Add(retryAfter)
+			l.mu.Unlock()
+		} else {
+			b.mu.Lock()
+			b.remaining = 0
+			b.reset = time.Now().Add(retryAfter)
+			b.mu.Unlock()
+		}
+
+		if r.Body != nil && r.GetBody == nil {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		if r.GetBody != nil {
+			if r.Body, err = r.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// reserve waits for the bucket to have a remaining request and reserves it, reporting the waits for its reset to the observer.
+//
+// Once the bucket reset, it is refilled when no request is in flight,
+// as their responses tell the new state of the bucket, and buckets without known limits allow one request at a time.
+func (b *bucket) reserve(ctx context.Context, route string, o Observer) error {
+	for {
+		b.mu.Lock()
+		if b.remaining <= 0 && b.inflight == 0 && !time.Now().Before(b.reset) {
+			b.remaining = max(b.limit, 1)
+		}
+		if b.remaining > 0 {
+			b.remaining--
+			b.inflight++
+			b.mu.Unlock()
+			return nil
+		}
+
+		if b.answered == nil {
+			b.answered = make(chan struct{})
+		}
+		wait, answered := time.Until(b.reset), b.answered
+		b.mu.Unlock()
+
+		if wait > 0 {
+			o.rateLimit(route, wait, false)
+			if err := sleep(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+
+		select {
+		case <-answered:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// cancel gives back the reservation of a request which wasn't answered
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remaining++
+	b.done()
+}
+
+// done marks a request as answered, waking up the requests waiting for it.
+// The mutex of the bucket must be held.
+func (b *bucket) done() {
+	b.inflight--
+	if b.answered != nil {
+		close(b.answered)
+		b.answered = nil
+	}
+}
+
+// bucket returns the bucket of the route, which must be released once the request is done
+func (l *RateLimiter) bucket(route string) *bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if time.Since(l.swept) >= sweepInterval {
+		l.sweep()
+	}
+
+	key := route
+	if hash, ok := l.hashes[route]; ok {
+		key = hash
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{remaining: 1}
+		l.buckets[key] = b
+	}
+	b.users++
+	return b
+}
+
+// release marks the request as done with the bucket
+func (l *RateLimiter) release(b *bucket) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b.users--
+}
+
+// sweep evicts the buckets which reset and aren't in use, along with the bucket hashes of their routes.
+// The mutex of the RateLimiter must be held.
+func (l *RateLimiter) sweep() {
+	l.swept = time.Now()
+	for key, b := range l.buckets {
+		if b.users > 0 {
+			continue
+		}
+
+		b.mu.Lock()
+		reset := b.reset
+		b.mu.Unlock()
+		if l.swept.After(reset) {
+			delete(l.buckets, key)
+		}
+	}
+
+	for route, key := range l.hashes {
+		if _, ok := l.buckets[key]; !ok {
+			delete(l.hashes, route)
+		}
+	}
+}
+
+// update updates the bucket with the rate limit headers of the response to a request which reserved one of its requests,
+// and records the bucket hash of the route, so that routes sharing it share their bucket.
+//
+// The requests still in flight may not be counted by the remaining requests of the response,
+// as such they are subtracted from them.
+func (l *RateLimiter) update(b *bucket, route string, major string, resp *http.Response) {
+	h := resp.Header
+	if hash := h.Get("X-RateLimit-Bucket"); hash != "" {
+		key := hash + " " + major
+		l.mu.Lock()
+		l.hashes[route] = key
+		if _, ok := l.buckets[key]; !ok {
+			l.buckets[key] = b
+		}
+		l.mu.Unlock()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done()
+
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		b.remaining = 1 // no rate limit information
+		return
+	}
+	remaining = max(remaining-b.inflight, 0)
+	if time.Now().Before(b.reset) {
+		remaining = min(remaining, b.remaining) // responses may be answered out of order
+	}
+	b.remaining = remaining
+	if limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil {
+		b.limit = limit
+	}
+
+	if resetAfter, err := strconv.ParseFloat(h.Get("X-RateLimit-Reset-After"), 64); err == nil {
+		b.reset = time.Now().Add(seconds(resetAfter))
+	} else if reset, err := strconv.ParseFloat(h.Get("X-RateLimit-Reset"), 64); err == nil {
+		b.reset = time.Unix(0, int64(reset*float64(time.Second)))
+	}
+}
+
+// untilGlobalReset returns how long requests must wait for the global rate limit
+func (l *RateLimiter) untilGlobalReset() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return time.Until(l.globalReset)
+}
+
+// routeKey returns the rate limit route of the request, and its major parameters.
+//
+// Snowflakes identify the route only when they are major parameters,
+// that is channel, guild and webhook IDs.
+// Webhook and interaction tokens never do, so that routes and buckets don't grow with each interaction.
+func routeKey(method string, path string) (route string, major string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var majors []string
+	for i, s := range segments {
+		if i == 0 || !isSnowflake(s) {
+			continue
+		}
+
+		switch segments[i-1] {
+		case "channels", "guilds", "webhooks":
+			majors = append(majors, s)
+		default:
+			segments[i] = ":id"
+		}
+
+		if (segments[i-1] == "webhooks" || segments[i-1] == "interactions") && i+1 < len(segments) {
+			segments[i+1] = ":token"
+		}
+	}
+
+	return method + " " + strings.Join(segments, "/"), strings.Join(majors, "/")
+}
+
+func isSnowflake(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// isGlobal reports wether the 429 response is due to the global rate limit
+func isGlobal(resp *http.Response) bool {
+	return resp.Header.Get("X-RateLimit-Global") == "true" || resp.Header.Get("X-RateLimit-Scope") == "global"
+}
+
+// parseRetryAfter returns how long to wait before retrying a request answered with 429,
+// from the `Retry-After` header or the `retry_after` field of the body
+func parseRetryAfter(resp *http.Response) time.Duration {
+	if after, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil {
+		return seconds(after)
+	}
+
+	var body struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body = io.NopCloser(strings.NewReader(string(b)))
+	if err := json.Unmarshal(b, &body); err == nil {
+		return seconds(body.RetryAfter)
+	}
+	return time.Second
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// sleep waits for d, or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
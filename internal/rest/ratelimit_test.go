@@ -0,0 +1,233 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRouteKey(t *testing.T) {
+	tt := []struct {
+		Path  string
+		Route string
+		Major string
+	}{
+		{
+			Path:  "/api/v10/applications/1234/commands/5678",
+			Route: "GET api/v10/applications/:id/commands/:id",
+		},
+		{
+			Path:  "/api/v10/channels/1234/messages/5678",
+			Route: "GET api/v10/channels/1234/messages/:id",
+			Major: "1234",
+		},
+		{
+			Path:  "/api/v10/webhooks/1234/token/messages/@original",
+			Route: "GET api/v10/webhooks/1234/:token/messages/@original",
+			Major: "1234",
+		},
+		{
+			Path:  "/api/v10/interactions/5678/token/callback",
+			Route: "GET api/v10/interactions/:id/:token/callback",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Path, func(t *testing.T) {
+			route, major := routeKey(http.MethodGet, tc.Path)
+			if route != tc.Route || major != tc.Major {
+				t.Errorf("got %q %q, expected %q %q", route, major, tc.Route, tc.Major)
+			}
+		})
+	}
+}
+
+func TestRateLimiterBucket(t *testing.T) {
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "0.2")
+	}))
+	defer s.Close()
+
+	l := NewRateLimiter()
+	do := func(path string) {
+		req, _ := http.NewRequest(http.MethodGet, s.URL+path, nil)
+		resp, err := l.Do(s.Client(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	start := time.Now()
+	do("/channels/1234/messages/1")
+	if time.Since(start) > 150*time.Millisecond {
+		t.Fatal("first request should not wait")
+	}
+
+	// another route sharing the bucket waits for its reset
+	do("/channels/1234/messages/2")
+	do("/channels/1234/pins")
+	do("/channels/1234/pins")
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("requests were not queued, took %s", elapsed)
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 calls, got %d", calls)
+	}
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	var calls int32
+	arrived := make(chan struct{}, 2)
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-RateLimit-Limit", "5")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(5-int(n)))
+		w.Header().Set("X-RateLimit-Reset-After", "10")
+		if n > 1 {
+			arrived <- struct{}{}
+			<-release
+		}
+	}))
+	defer s.Close()
+
+	l := NewRateLimiter()
+	do := func() error {
+		req, _ := http.NewRequest(http.MethodGet, s.URL+"/channels/1234/messages", nil)
+		resp, err := l.Do(s.Client(), req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+	if err := do(); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 2)
+	go func() { errs <- do() }()
+	go func() { errs <- do() }()
+
+	// both requests are sent while neither is answered
+	for i := 0; i < 2; i++ {
+		select {
+		case <-arrived:
+		case <-time.After(time.Second):
+			t.Fatal("requests sharing a bucket with remaining requests should be sent concurrently")
+		}
+	}
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+
+	b := l.bucket("GET channels/1234/messages")
+	defer l.release(b)
+	if b.remaining < 1 || b.remaining > 2 || b.inflight != 0 {
+		t.Errorf("expected at most 2 remaining requests and none in flight, got %d and %d", b.remaining, b.inflight)
+	}
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Remaining", "4")
+		w.Header().Set("X-RateLimit-Reset-After", "0.05")
+	}))
+	defer s.Close()
+
+	l := NewRateLimiter()
+	for _, token := range []string{"a", "b", "c"} {
+		req, _ := http.NewRequest(http.MethodPatch, s.URL+"/webhooks/1234/"+token+"/messages/@original", nil)
+		resp, err := l.Do(s.Client(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	if len(l.buckets) != 2 || len(l.hashes) != 1 {
+		t.Fatalf("tokens should share their bucket, got %d buckets and %d hashes", len(l.buckets), len(l.hashes))
+	}
+
+	inUse := l.bucket("GET channels/1234")
+	time.Sleep(100 * time.Millisecond)
+	l.mu.Lock()
+	l.sweep()
+	l.mu.Unlock()
+
+	if len(l.buckets) != 1 || len(l.hashes) != 0 {
+		t.Errorf("buckets which reset should be evicted, got %d buckets and %d hashes", len(l.buckets), len(l.hashes))
+	}
+	if l.buckets["GET channels/1234"] != inUse {
+		t.Error("buckets in use should not be evicted")
+	}
+	l.release(inUse)
+}
+
+func TestRateLimiterRetry(t *testing.T) {
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if string(b) != "payload" {
+			t.Errorf("body was not replayed, got %q", b)
+		}
+
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0.1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.Header().Set("X-RateLimit-Global", "true")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.1, "global": true}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer s.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, s.URL, strings.NewReader("payload"))
+	start := time.Now()
+	resp, err := NewRateLimiter().Do(s.Client(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected %d, got %d", http.StatusNoContent, resp.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("retries did not wait, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterContext(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if _, err := NewRateLimiter().Do(s.Client(), req); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
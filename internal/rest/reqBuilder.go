@@ -2,27 +2,57 @@ package rest
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
-	"os"
 	"path"
+	"strconv"
+	"strings"
 )
 
 type Request struct {
-	root string
-	path string
-	body io.Reader
+	ctx       context.Context
+	root      string
+	userAgent string
+	path      string
+	query     url.Values
+	body      io.Reader
 }
 
-var API = "https://discord.com/api/v10"
+const (
+	DefaultRoot      = "https://discord.com/api"
+	DefaultVersion   = 10
+	DefaultUserAgent = "DiscordBot (https://github.com/Karitham/corde)"
+)
+
+// Client is the configuration of the API requests are sent to.
+// Its zero value targets the default version of the discord API.
+type Client struct {
+	Root      string // the root of the API, without version
+	Version   int
+	UserAgent string
+}
+
+// Req returns a request to the API, its HTTP requests being bound to ctx
+func (c Client) Req(ctx context.Context, paths ...any) *Request {
+	root, version, userAgent := c.Root, c.Version, c.UserAgent
+	if root == "" {
+		root = DefaultRoot
+	}
+	if version == 0 {
+		version = DefaultVersion
+	}
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
 
-func Req(paths ...any) *Request {
 	r := &Request{
-		root: API,
+		ctx:       ctx,
+		root:      strings.TrimSuffix(root, "/") + "/v" + strconv.Itoa(version),
+		userAgent: userAgent,
 	}
 	r.Append(paths...)
 
@@ -32,9 +62,18 @@ func Req(paths ...any) *Request {
 func (r *Request) URL() string {
 	u, _ := url.Parse(r.root)
 	u.Path = path.Join(u.Path, r.path)
+	u.RawQuery = r.query.Encode()
 	return u.String()
 }
 
+func (r *Request) Query(k string, v string) *Request {
+	if r.query == nil {
+		r.query = url.Values{}
+	}
+	r.query.Add(k, v)
+	return r
+}
+
 func (r *Request) JSONBody(v any) *Request {
 	b := &bytes.Buffer{}
 	json.NewEncoder(b).Encode(v)
@@ -89,23 +128,23 @@ func Authorization(tok string) func(*http.Request) {
 	}
 }
 
+// BearerAuthorization sets the authorization header to the OAuth2 bearer token
+func BearerAuthorization(tok string) func(*http.Request) {
+	return func(r *http.Request) {
+		r.Header.Set("authorization", "Bearer "+tok)
+	}
+}
+
 func (r *Request) new(method string, body io.Reader, opts ...func(*http.Request)) *http.Request {
-	req, err := http.NewRequest(method, r.URL(), body)
+	req, err := http.NewRequestWithContext(r.ctx, method, r.URL(), body)
 	if err != nil {
 		return nil
 	}
 
+	req.Header.Set("user-agent", r.userAgent)
 	for _, o := range opts {
 		o(req)
 	}
 
-	if os.Getenv("CORDE_DUMP_HTTP_REQUEST") != "" {
-		dump, err := httputil.DumpRequest(req, true)
-		if err != nil {
-			fmt.Println(err)
-		}
-		fmt.Println(string(dump))
-	}
-
 	return req
 }
@@ -0,0 +1,241 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMaxAttempts = 3
+	DefaultMinBackoff  = 100 * time.Millisecond
+	DefaultMaxBackoff  = 5 * time.Second
+)
+
+// RetryPolicy retries requests failing with transient errors.
+// Its zero value uses the default attempts and backoffs.
+type RetryPolicy struct {
+	MaxAttempts        int
+	MinBackoff         time.Duration
+	MaxBackoff         time.Duration
+	RetryNonIdempotent bool
+}
+
+// Client returns a Doer retrying the requests of d according to the policy,
+// logging them with logger and reporting their outcome to the observer
+func (p RetryPolicy) Client(d Doer, logger *slog.Logger, observer Observer) Doer {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = DefaultMaxAttempts
+	}
+	if p.MinBackoff == 0 {
+		p.MinBackoff = DefaultMinBackoff
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = DefaultMaxBackoff
+	}
+
+	return retryClient{policy: p, doer: d, logger: logger, observer: observer}
+}
+
+type retryClient struct {
+	policy   RetryPolicy
+	doer     Doer
+	logger   *slog.Logger
+	observer Observer
+}
+
+func (c retryClient) Do(r *http.Request) (*http.Response, error) {
+	start := time.Now()
+	if c.logger.Enabled(r.Context(), slog.LevelDebug) {
+		c.logger.DebugContext(r.Context(), "sending API request", slog.String("method", r.Method), slog.String("dump", dump(r)))
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doer.Do(r)
+		if attempt >= c.policy.MaxAttempts || !c.retryable(r, resp, err) || !replayBody(r) {
+			c.done(r, resp, err, attempt, start)
+			return resp, err
+		}
+
+		wait := c.policy.backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				wait = min(after, c.policy.MaxBackoff)
+			}
+			resp.Body.Close()
+		}
+
+		if err := sleep(r.Context(), wait); err != nil {
+			c.done(r, nil, err, attempt, start)
+			return nil, err
+		}
+	}
+}
+
+// replayBody rewinds the body of the request so that it can be sent again.
+// It reports false if the body can't be replayed.
+func replayBody(r *http.Request) bool {
+	if r.Body == nil || r.Body == http.NoBody {
+		return true
+	}
+	if r.GetBody == nil {
+		return false
+	}
+
+	body, err := r.GetBody()
+	if err != nil {
+		return false
+	}
+	r.Body = body
+	return true
+}
+
+// retryAfter returns how long discord asks to wait before retrying, in fractional seconds,
+// from the `Retry-After` header, or the `X-RateLimit-Reset-After` one
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	for _, h := range []string{"Retry-After", "X-RateLimit-Reset-After"} {
+		if after, err := strconv.ParseFloat(resp.Header.Get(h), 64); err == nil && after >= 0 {
+			return seconds(after), true
+		}
+	}
+	return 0, false
+}
+
+// done logs the outcome of the request, as an error if it failed, and as a warning on client errors,
+// and reports it to the observer
+func (c retryClient) done(r *http.Request, resp *http.Response, err error, attempts int, start time.Time) {
+	route := endpoint(r.Method, r.URL.Path)
+	latency := time.Since(start)
+	attrs := []any{
+		slog.String("method", r.Method),
+		slog.String("route", route),
+		slog.Int("retries", attempts-1),
+		slog.Duration("latency", latency),
+	}
+
+	status := 0
+	if err == nil {
+		status = resp.StatusCode
+	}
+	c.observer.request(r.Method, route, status, attempts-1, latency)
+
+	switch {
+	case err != nil:
+		c.logger.ErrorContext(r.Context(), "API request failed", append(attrs, slog.Any("error", err))...)
+	case resp.StatusCode >= 500:
+		c.logger.ErrorContext(r.Context(), "API request failed", append(attrs, slog.Int("status", resp.StatusCode))...)
+	case resp.StatusCode >= 400:
+		c.logger.WarnContext(r.Context(), "API request failed", append(attrs, slog.Int("status", resp.StatusCode))...)
+	default:
+		c.logger.DebugContext(r.Context(), "API request", append(attrs, slog.Int("status", resp.StatusCode))...)
+	}
+}
+
+// endpoint returns the templated endpoint of the request, such as `PATCH /webhooks/{id}/{token}/messages/@original`.
+// Unlike its rate limit route, it holds neither IDs nor tokens, so that it can be logged and used as a metric label.
+func endpoint(method string, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if isVersion(s) {
+			segments = segments[i+1:]
+			break
+		}
+	}
+
+	for i, s := range segments {
+		switch {
+		case isSnowflake(s):
+			segments[i] = "{id}"
+		case i > 0 && segments[i-1] == "reactions":
+			segments[i] = "{emoji}"
+		case i > 1 && segments[i-1] == "{id}" && (segments[i-2] == "webhooks" || segments[i-2] == "interactions"):
+			segments[i] = "{token}"
+		}
+	}
+
+	return method + " /" + strings.Join(segments, "/")
+}
+
+// isVersion reports wether the path segment is the version of the API, such as `v10`
+func isVersion(s string) bool {
+	_, err := strconv.Atoi(strings.TrimPrefix(s, "v"))
+	return len(s) > 1 && s[0] == 'v' && err == nil
+}
+
+// dump returns the dump of the request, without its authorization
+func dump(r *http.Request) string {
+	c := r.Clone(r.Context())
+	if c.Header.Get("authorization") != "" {
+		c.Header.Set("authorization", "[redacted]")
+	}
+
+	c.Body = nil
+	if r.GetBody != nil {
+		c.Body, _ = r.GetBody()
+	}
+
+	b, err := httputil.DumpRequestOut(c, c.Body != nil)
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
+
+// retryable reports wether the outcome of the request is transient, and the request can safely be sent again
+func (c retryClient) retryable(r *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if r.Context().Err() != nil {
+			return false
+		}
+		return isIdempotent(r.Method) || c.policy.RetryNonIdempotent || notSent(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(r.Method) || c.policy.RetryNonIdempotent
+	}
+	return false
+}
+
+// backoff returns the exponential backoff before the attempt following the given one,
+// with a random jitter of up to half of it
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff << (attempt - 1)
+	if d > p.MaxBackoff || d <= 0 {
+		d = p.MaxBackoff
+	}
+
+	half := int64(d / 2)
+	if half <= 0 {
+		return d
+	}
+	return time.Duration(half + rand.Int63n(half))
+}
+
+// isIdempotent reports wether sending a request with the method multiple times has the same effect as sending it once.
+// PATCH requests are considered idempotent, as those of discord set fields to the values they are given.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+// notSent reports wether the error happened before the request was sent, such as when the connection was refused
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && !errors.Is(err, context.DeadlineExceeded)
+}
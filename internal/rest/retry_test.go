@@ -0,0 +1,249 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// respond returns a doer answering with the given statuses in order, recording the bodies it receives
+func respond(bodies *[]string, outcomes ...any) Doer {
+	return doerFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Body != nil {
+			b, _ := io.ReadAll(r.Body)
+			*bodies = append(*bodies, string(b))
+		} else {
+			*bodies = append(*bodies, "")
+		}
+
+		o := outcomes[0]
+		if len(outcomes) > 1 {
+			outcomes = outcomes[1:]
+		}
+
+		if err, ok := o.(error); ok {
+			return nil, err
+		}
+		return &http.Response{StatusCode: o.(int), Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	refused := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	reset := &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}
+
+	tt := []struct {
+		Name     string
+		Policy   RetryPolicy
+		Method   string
+		Outcomes []any
+		Attempts int
+		Status   int
+	}{
+		{
+			Name:     "Retry idempotent",
+			Policy:   policy,
+			Method:   http.MethodPatch,
+			Outcomes: []any{http.StatusBadGateway, reset, http.StatusOK},
+			Attempts: 3,
+			Status:   http.StatusOK,
+		},
+		{
+			Name:     "Max attempts",
+			Policy:   policy,
+			Method:   http.MethodGet,
+			Outcomes: []any{http.StatusServiceUnavailable},
+			Attempts: 3,
+			Status:   http.StatusServiceUnavailable,
+		},
+		{
+			Name:     "Client errors are not retried",
+			Policy:   policy,
+			Method:   http.MethodGet,
+			Outcomes: []any{http.StatusNotFound},
+			Attempts: 1,
+			Status:   http.StatusNotFound,
+		},
+		{
+			Name:     "POST is not retried once sent",
+			Policy:   policy,
+			Method:   http.MethodPost,
+			Outcomes: []any{http.StatusBadGateway, http.StatusOK},
+			Attempts: 1,
+			Status:   http.StatusBadGateway,
+		},
+		{
+			Name:     "POST is retried if not sent",
+			Policy:   policy,
+			Method:   http.MethodPost,
+			Outcomes: []any{refused, http.StatusOK},
+			Attempts: 2,
+			Status:   http.StatusOK,
+		},
+		{
+			Name:     "POST is retried if allowed",
+			Policy:   RetryPolicy{MinBackoff: time.Millisecond, RetryNonIdempotent: true},
+			Method:   http.MethodPost,
+			Outcomes: []any{http.StatusBadGateway, http.StatusOK},
+			Attempts: 2,
+			Status:   http.StatusOK,
+		},
+		{
+			Name:     "Disabled",
+			Policy:   RetryPolicy{MaxAttempts: 1},
+			Method:   http.MethodGet,
+			Outcomes: []any{http.StatusBadGateway, http.StatusOK},
+			Attempts: 1,
+			Status:   http.StatusBadGateway,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			var bodies []string
+			req, _ := http.NewRequest(tc.Method, "https://discord.com/api/v10/webhooks/1/token", strings.NewReader("payload"))
+
+			resp, err := tc.Policy.Client(respond(&bodies, tc.Outcomes...), slog.New(slog.NewTextHandler(io.Discard, nil)), Observer{}).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tc.Status {
+				t.Errorf("expected status %d, got %d", tc.Status, resp.StatusCode)
+			}
+			if len(bodies) != tc.Attempts {
+				t.Errorf("expected %d attempts, got %d", tc.Attempts, len(bodies))
+			}
+			for _, b := range bodies {
+				if b != "payload" {
+					t.Errorf("body was not replayed, got %q", b)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		if d := p.backoff(attempt + 1); d < max/2 || d > max {
+			t.Errorf("backoff of attempt %d should be between %s and %s, got %s", attempt+1, max/2, max, d)
+		}
+	}
+}
+
+func TestRetryLogging(t *testing.T) {
+	var bodies []string
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	policy := RetryPolicy{MinBackoff: time.Millisecond}
+
+	req, _ := http.NewRequest(http.MethodPatch, "https://discord.com/api/v10/applications/1234/commands/5678", strings.NewReader("payload"))
+	req.Header.Set("authorization", "Bot secret")
+	if _, err := policy.Client(respond(&bodies, http.StatusBadGateway, http.StatusOK), logger, Observer{}).Do(req); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := buf.String()
+	for _, expect := range []string{"payload", "[redacted]", "route=\"PATCH /applications/{id}/commands/{id}\"", "retries=1", "status=200"} {
+		if !strings.Contains(logs, expect) {
+			t.Errorf("expected logs to contain %s, got %s", expect, logs)
+		}
+	}
+	if strings.Contains(logs, "secret") {
+		t.Errorf("logs leaked the token: %s", logs)
+	}
+	if bodies[1] != "payload" {
+		t.Errorf("dumping consumed the body, got %q", bodies[1])
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	for _, tc := range []struct {
+		Header http.Header
+		Expect time.Duration
+		OK     bool
+	}{
+		{Header: http.Header{"Retry-After": {"1.5"}}, Expect: 1500 * time.Millisecond, OK: true},
+		{Header: http.Header{"X-Ratelimit-Reset-After": {"0.25"}}, Expect: 250 * time.Millisecond, OK: true},
+		{Header: http.Header{"Retry-After": {"soon"}}},
+		{Header: http.Header{}},
+	} {
+		after, ok := retryAfter(&http.Response{Header: tc.Header})
+		if after != tc.Expect || ok != tc.OK {
+			t.Errorf("expected %s (%t) for %v, got %s (%t)", tc.Expect, tc.OK, tc.Header, after, ok)
+		}
+	}
+}
+
+func TestRetryAfterMaxBackoff(t *testing.T) {
+	attempts := 0
+	d := doerFunc(func(*http.Request) (*http.Response, error) {
+		attempts++
+		if attempts > 1 {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+		}
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": {"3600"}}, Body: http.NoBody}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "https://discord.com/api/v10/users/@me", nil)
+	start := time.Now()
+	resp, err := RetryPolicy{MaxBackoff: 5 * time.Millisecond}.Client(d, slog.New(slog.NewTextHandler(io.Discard, nil)), Observer{}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || attempts != 2 {
+		t.Errorf("expected status %d after 2 attempts, got %d after %d", http.StatusOK, resp.StatusCode, attempts)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Retry-After should be bounded by MaxBackoff, waited %s", elapsed)
+	}
+}
+
+func TestRetryUnreplayableObserved(t *testing.T) {
+	var bodies []string
+	var observed []int
+	observer := Observer{OnRequest: func(_ string, _ string, status int, retries int, _ time.Duration) {
+		observed = append(observed, status, retries)
+	}}
+
+	req, _ := http.NewRequest(http.MethodPatch, "https://discord.com/api/v10/applications/1234/commands/5678", strings.NewReader("payload"))
+	req.GetBody = nil
+	resp, err := RetryPolicy{MinBackoff: time.Millisecond}.Client(respond(&bodies, http.StatusBadGateway, http.StatusOK), slog.New(slog.NewTextHandler(io.Discard, nil)), observer).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusBadGateway || len(bodies) != 1 {
+		t.Errorf("expected the request not to be retried, got status %d after %d attempts", resp.StatusCode, len(bodies))
+	}
+	if len(observed) != 2 || observed[0] != http.StatusBadGateway || observed[1] != 0 {
+		t.Errorf("expected the request to be observed, got %v", observed)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	for path, expect := range map[string]string{
+		"/api/v10/webhooks/1234/secret_token/messages/@original": "PATCH /webhooks/{id}/{token}/messages/@original",
+		"/v10/channels/1234/messages/5678/reactions/👍/@me":       "PATCH /channels/{id}/messages/{id}/reactions/{emoji}/@me",
+		"/api/v10/applications/1234/guilds/5678/commands":        "PATCH /applications/{id}/guilds/{id}/commands",
+		"/api/v10/users/@me": "PATCH /users/@me",
+	} {
+		if got := endpoint(http.MethodPatch, path); got != expect {
+			t.Errorf("expected %s for %s, got %s", expect, path, got)
+		}
+	}
+}
@@ -8,8 +8,9 @@ import (
 	"net/http"
 )
 
-// Verify is a middleware to verify Interaction payloads
-func Verify(publicKey string) func(http.Handler) http.Handler {
+// Verify is a middleware to verify Interaction payloads,
+// calling onFailure, if set, when one is rejected
+func Verify(publicKey string, onFailure func()) func(http.Handler) http.Handler {
 	pk, err := hex.DecodeString(publicKey)
 	if err != nil {
 		panic("invalid public key")
@@ -22,14 +23,14 @@ func Verify(publicKey string) func(http.Handler) http.Handler {
 
 			b, oldBody := &bytes.Buffer{}, r.Body
 			if _, err := b.ReadFrom(r.Body); err != nil {
-				w.WriteHeader(http.StatusUnauthorized)
+				unauthorized(w, onFailure)
 				return
 			}
 			oldBody.Close()
 
 			r.Body = io.NopCloser(b)
 			if !ed25519.Verify(pk, append([]byte(timestamp), b.Bytes()...), sig) {
-				w.WriteHeader(http.StatusUnauthorized)
+				unauthorized(w, onFailure)
 				return
 			}
 
@@ -37,3 +38,11 @@ func Verify(publicKey string) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// unauthorized rejects the request, calling onFailure if it's set
+func unauthorized(w http.ResponseWriter, onFailure func()) {
+	if onFailure != nil {
+		onFailure()
+	}
+	w.WriteHeader(http.StatusUnauthorized)
+}
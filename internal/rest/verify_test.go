@@ -54,7 +54,7 @@ func TestVerify(t *testing.T) {
 		},
 	}
 
-	s := httptest.NewServer(Verify(hex.EncodeToString(pub))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	s := httptest.NewServer(Verify(hex.EncodeToString(pub), nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write(nil)
 	})))
 	defer s.Close()
@@ -0,0 +1,140 @@
+package corde
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Localizations maps discord locales, such as `fr` or `en-US`, to localized strings
+// https://discord.com/developers/docs/reference#locales
+type Localizations map[string]string
+
+// with returns a copy of the localizations with s set in the locale,
+// so that localizing a copied builder doesn't affect the original.
+// Empty strings are not set.
+func (l Localizations) with(locale string, s string) Localizations {
+	if s == "" {
+		return l
+	}
+
+	c := make(Localizations, len(l)+1)
+	for k, v := range l {
+		c[k] = v
+	}
+	c[locale] = s
+	return c
+}
+
+// CommandCatalog holds the localizations of commands, by locale then by command name.
+//
+// It is usually loaded from a JSON file with LoadCommandCatalog, mirroring the command tree:
+//
+//	{
+//		"fr": {
+//			"todo": {
+//				"name": "tâches",
+//				"description": "gérer vos tâches",
+//				"options": {
+//					"add": {
+//						"name": "ajouter",
+//						"description": "ajouter une tâche",
+//						"options": {
+//							"priority": {
+//								"name": "priorité",
+//								"description": "la priorité de la tâche",
+//								"choices": {"high": "haute", "low": "basse"}
+//							}
+//						}
+//					}
+//				}
+//			}
+//		}
+//	}
+//
+// Choices are keyed by their default name.
+type CommandCatalog map[string]map[string]CatalogEntry
+
+// CatalogEntry is the localization of a command or an option
+type CatalogEntry struct {
+	Name        string                  `json:"name,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	Options     map[string]CatalogEntry `json:"options,omitempty"`
+	Choices     map[string]string       `json:"choices,omitempty"`
+}
+
+// LoadCommandCatalog decodes a JSON command catalog
+func LoadCommandCatalog(r io.Reader) (CommandCatalog, error) {
+	var c CommandCatalog
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LocalizeAll localizes every command with the catalog
+func (c CommandCatalog) LocalizeAll(cmds []CreateCommander) []CreateCommander {
+	localized := make([]CreateCommander, 0, len(cmds))
+	for _, cmd := range cmds {
+		localized = append(localized, c.Localize(cmd.createCommand()))
+	}
+	return localized
+}
+
+// Localize returns the command, with the localizations of the catalog set on it, its options and their choices.
+// Localizations already set on the command are kept, unless the catalog overrides them.
+func (c CommandCatalog) Localize(cmd CreateCommand) CreateCommand {
+	entries := make(map[string]CatalogEntry, len(c))
+	for locale, cmds := range c {
+		if e, ok := cmds[cmd.Name]; ok {
+			entries[locale] = e
+		}
+	}
+
+	cmd = cmd.createCommand()
+	for locale, e := range entries {
+		cmd = cmd.Localize(locale, e.Name, e.Description)
+	}
+	cmd.Options = localizeOptions(cmd.Options, entries)
+
+	return cmd
+}
+
+// localizeOptions returns the options with their localizations set from the entries of their parent, by locale
+func localizeOptions(options []CreateOptioner, entries map[string]CatalogEntry) []CreateOptioner {
+	if len(options) == 0 {
+		return options
+	}
+
+	localized := make([]CreateOptioner, 0, len(options))
+	for _, opt := range options {
+		o := opt.createOption()
+
+		sub := make(map[string]CatalogEntry, len(entries))
+		for locale, e := range entries {
+			oe, ok := e.Options[o.Name]
+			if !ok {
+				continue
+			}
+
+			sub[locale] = oe
+			o.NameLocalizations = o.NameLocalizations.with(locale, oe.Name)
+			o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, oe.Description)
+		}
+
+		if len(o.Choices) > 0 {
+			choices := make([]Choice[any], 0, len(o.Choices))
+			for _, ch := range o.Choices {
+				for locale, oe := range sub {
+					ch = ch.Localize(locale, oe.Choices[ch.Name])
+				}
+				choices = append(choices, ch)
+			}
+			o.Choices = choices
+		}
+
+		o.Options = localizeOptions(o.Options, sub)
+		localized = append(localized, o)
+	}
+
+	return localized
+}
@@ -0,0 +1,72 @@
+package corde_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Karitham/corde"
+	"github.com/matryer/is"
+)
+
+const sampleCatalog = `{
+	"fr": {
+		"todo": {
+			"name": "tâches",
+			"description": "gérer vos tâches",
+			"options": {
+				"add": {
+					"name": "ajouter",
+					"options": {
+						"priority": {
+							"name": "priorité",
+							"description": "la priorité de la tâche",
+							"choices": {"high": "haute"}
+						}
+					}
+				}
+			}
+		}
+	},
+	"de": {
+		"todo": {"name": "aufgaben"}
+	}
+}`
+
+func TestCommandCatalog(t *testing.T) {
+	assert := is.New(t)
+
+	catalog, err := corde.LoadCommandCatalog(strings.NewReader(sampleCatalog))
+	assert.NoErr(err)
+
+	cmd := corde.NewSlashCommand("todo", "manage your todos",
+		corde.NewSubcommand("add", "add a todo",
+			corde.NewStringOption("priority", "the priority of the todo", false,
+				corde.Choice[string]{Name: "high", Value: "high"},
+				corde.Choice[string]{Name: "low", Value: "low"}.Localize("ja", "低い"),
+			),
+		),
+	).Localize("ja", "タスク", "")
+
+	b, err := json.Marshal(catalog.Localize(cmd))
+	assert.NoErr(err)
+
+	var got corde.Command
+	assert.NoErr(json.Unmarshal(b, &got))
+
+	assert.Equal(got.NameLocalizations, corde.Localizations{"fr": "tâches", "de": "aufgaben", "ja": "タスク"})
+	assert.Equal(got.DescriptionLocalizations, corde.Localizations{"fr": "gérer vos tâches"})
+
+	add := got.Options[0]
+	assert.Equal(add.NameLocalizations, corde.Localizations{"fr": "ajouter"})
+	assert.Equal(len(add.DescriptionLocalizations), 0)
+
+	priority := add.Options[0]
+	assert.Equal(priority.NameLocalizations, corde.Localizations{"fr": "priorité"})
+	assert.Equal(priority.DescriptionLocalizations, corde.Localizations{"fr": "la priorité de la tâche"})
+	assert.Equal(priority.Choices[0].NameLocalizations, corde.Localizations{"fr": "haute"})
+	assert.Equal(priority.Choices[1].NameLocalizations, corde.Localizations{"ja": "低い"})
+
+	// the original command is left untouched
+	assert.Equal(cmd.NameLocalizations, corde.Localizations{"ja": "タスク"})
+}
@@ -0,0 +1,47 @@
+package corde
+
+import (
+	"context"
+	"log/slog"
+)
+
+// loggerKey is the context key holding the logger of the mux routing the interaction
+type loggerKey struct{}
+
+// loggerFrom returns the logger of the mux routing the interaction, or the default logger
+func loggerFrom(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+// logger returns the Logger of the mux, or the default logger if it isn't set
+func (m *Mux) logger() *slog.Logger {
+	if m.Logger != nil {
+		return m.Logger
+	}
+	return slog.Default()
+}
+
+// interactionAttrs returns the attributes identifying the interaction in logs
+func interactionAttrs(i *Interaction[JsonRaw]) []any {
+	return []any{
+		slog.String("id", i.ID.String()),
+		slog.Int("type", int(i.Type)),
+		slog.String("route", i.Route),
+		slog.String("guild_id", i.GuildID.String()),
+		slog.String("user_id", i.user().ID.String()),
+	}
+}
+
+// logInteraction logs the interaction once handled at the debug level, with the type of its response if it was answered.
+// Failures are logged where they happen.
+func logInteraction(ctx context.Context, i *Interaction[JsonRaw], e *InteractionEvent) {
+	attrs := append(interactionAttrs(i), slog.Duration("latency", e.Latency))
+	if e.ResponseType != 0 {
+		attrs = append(attrs, slog.Int("response_type", e.ResponseType))
+	}
+
+	loggerFrom(ctx).DebugContext(ctx, "handled interaction", attrs...)
+}
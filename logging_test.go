@@ -0,0 +1,78 @@
+package corde_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Karitham/corde"
+	"github.com/Karitham/corde/owmock"
+	"github.com/matryer/is"
+)
+
+// syncBuffer is a buffer safe for concurrent use, as handlers log from their own goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// records returns the JSON records logged so far
+func (b *syncBuffer) records() []map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		r := map[string]any{}
+		if json.Unmarshal([]byte(line), &r) == nil {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+func TestMuxLogger(t *testing.T) {
+	assert := is.New(t)
+
+	logs := &syncBuffer{}
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 0, "", corde.LoggerOpt(slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	mux.ButtonComponentE("click_one", func(_ context.Context, _ corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) error {
+		return errors.New("database unavailable")
+	})
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	_, err := owmock.NewWithClient(s.URL, s.Client()).Post(SampleComponent)
+	assert.NoErr(err)
+
+	var records []map[string]any
+	for start := time.Now(); len(records) < 2 && time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		records = logs.records()
+	}
+	assert.Equal(len(records), 2)
+
+	assert.Equal(records[0]["level"], "ERROR")
+	assert.Equal(records[0]["error"], "database unavailable")
+	assert.Equal(records[0]["route"], "click_one")
+
+	assert.Equal(records[1]["level"], "DEBUG")
+	assert.Equal(records[1]["msg"], "handled interaction")
+	assert.Equal(records[1]["id"], "846462639134605312")
+	assert.Equal(records[1]["user_id"], "53908232506183680")
+	assert.Equal(records[1]["response_type"], 4.0)
+	assert.True(records[1]["latency"] != nil)
+}
@@ -47,7 +47,7 @@ const (
 	CHANNEL_GUILD_CATEGORY
 	CHANNEL_GUILD_NEWS
 	CHANNEL_GUILD_STORE
-	CHANNEL_GUILD_NEWS_THREAD = iota + 3
+	CHANNEL_GUILD_NEWS_THREAD ChannelType = iota + 3
 	CHANNEL_GUILD_PUBLIC_THREAD
 	CHANNEL_GUILD_PRIVATE_THREAD
 	CHANNEL_GUILD_STAGE_VOICE
@@ -103,6 +103,21 @@ type Channel struct {
 	RateLimitPerUser     int         `json:"rate_limit_per_user,omitempty"`
 	LastPinTimestamp     Timestamp   `json:"last_pin_timestamp,omitempty"`
 	OwnerID              Snowflake   `json:"owner_id,omitempty"`
+	ParentID             Snowflake   `json:"parent_id,omitempty"`
+	// Permissions of the invoking user in the channel, only set on resolved channels
+	Permissions    Permissions     `json:"permissions,omitempty"`
+	ThreadMetadata *ThreadMetadata `json:"thread_metadata,omitempty"`
+}
+
+// ThreadMetadata holds the thread specific fields of a thread channel
+// https://discord.com/developers/docs/resources/channel#thread-metadata-object
+type ThreadMetadata struct {
+	Archived            bool      `json:"archived"`
+	AutoArchiveDuration int       `json:"auto_archive_duration"`
+	ArchiveTimestamp    Timestamp `json:"archive_timestamp"`
+	Locked              bool      `json:"locked"`
+	Invitable           bool      `json:"invitable,omitempty"`
+	CreateTimestamp     Timestamp `json:"create_timestamp,omitempty"`
 }
 
 // Overwrite
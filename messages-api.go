@@ -2,6 +2,7 @@ package corde
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -41,29 +42,21 @@ func toBodyMessage(w io.Writer, m Message) (string, error) {
 // CreateMessage creates a new message in a channel
 //
 // https://discord.com/developers/docs/resources/channel#create-message
-func (m *Mux) CreateMessage(channelID Snowflake, data Message) (*Message, error) {
+func (m *Mux) CreateMessage(ctx context.Context, channelID Snowflake, data Message) (*Message, error) {
 	body := &bytes.Buffer{}
 	contentType, err := toBodyMessage(body, data)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := m.Client.Do(
-		rest.Req("/channels", channelID, "messages").
+	msg := &Message{}
+	err = m.do(
+		m.api.Req(ctx, "/channels", channelID, "messages").
 			AnyBody(body).Post(m.authorize, rest.ContentType(contentType)),
+		msg,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create message: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode > 299 {
-		anyerr := map[string]any{}
-		json.NewDecoder(resp.Body).Decode(&anyerr)
-		return nil, fmt.Errorf("failed to create message: %+v", anyerr)
-	}
-
-	msg := &Message{}
-	json.NewDecoder(resp.Body).Decode(msg)
 	return msg, nil
 }
@@ -0,0 +1,275 @@
+// Package metrics records what a Mux does, and exposes it in the Prometheus text format,
+// without depending on the Prometheus client.
+//
+// Interactions are counted and timed by route and type, and requests to the API by method, route and status,
+// along with handlers not found, panics, errors, signature verification failures and rate limit waits.
+//
+//	mt := metrics.New()
+//	m := corde.NewMux(publicKey, appID, botToken, corde.ObserverOpt(mt))
+//
+//	http.Handle("/metrics", mt)
+//	http.Handle("/", m)
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Karitham/corde"
+)
+
+// DefaultBuckets are the upper bounds of the latency histograms, in seconds
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Names of the metrics
+const (
+	INTERACTIONS_TOTAL           = "corde_interactions_total"
+	INTERACTION_DURATION         = "corde_interaction_duration_seconds"
+	INTERACTIONS_NOT_FOUND_TOTAL = "corde_interactions_not_found_total"
+	INTERACTION_ERRORS_TOTAL     = "corde_interaction_errors_total"
+	INTERACTION_PANICS_TOTAL     = "corde_interaction_panics_total"
+	VERIFICATION_FAILURES_TOTAL  = "corde_verification_failures_total"
+	API_REQUESTS_TOTAL           = "corde_api_requests_total"
+	API_REQUEST_DURATION         = "corde_api_request_duration_seconds"
+	API_RETRIES_TOTAL            = "corde_api_retries_total"
+	API_RATE_LIMIT_WAITS_TOTAL   = "corde_api_rate_limit_waits_total"
+	API_RATE_LIMIT_WAIT_SECONDS  = "corde_api_rate_limit_wait_seconds_total"
+)
+
+var _ corde.Observer = (*Metrics)(nil)
+
+// Metrics is a corde.Observer recording metrics,
+// and an http.Handler serving them in the Prometheus text format
+type Metrics struct {
+	mu         sync.Mutex
+	buckets    []float64
+	counters   map[string]*counter
+	histograms map[string]*histogram
+}
+
+// counter is a counter, with its values by labels
+type counter struct {
+	help   string
+	values map[string]float64
+}
+
+// histogram is a histogram, with its series by labels
+type histogram struct {
+	help   string
+	series map[string]*series
+}
+
+type series struct {
+	counts []uint64 // the count of observations by bucket, not cumulated
+	sum    float64
+	count  uint64
+}
+
+// New returns Metrics timing with the given histogram buckets, in seconds, or DefaultBuckets if none are given
+func New(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	m := &Metrics{
+		buckets:    buckets,
+		counters:   map[string]*counter{},
+		histograms: map[string]*histogram{},
+	}
+
+	for name, help := range map[string]string{
+		INTERACTIONS_TOTAL:           "Interactions handled, by route, type and response type.",
+		INTERACTIONS_NOT_FOUND_TOTAL: "Interactions without a handler, by type.",
+		INTERACTION_ERRORS_TOTAL:     "Interactions whose handler returned an error, by route and type.",
+		INTERACTION_PANICS_TOTAL:     "Interactions whose handler panicked, by route and type.",
+		VERIFICATION_FAILURES_TOTAL:  "Inbound requests rejected for an invalid signature.",
+		API_REQUESTS_TOTAL:           "Requests to the API, by method, route and status.",
+		API_RETRIES_TOTAL:            "Retries of requests to the API, by method and route.",
+		API_RATE_LIMIT_WAITS_TOTAL:   "Waits for rate limits to reset, by route and scope.",
+		API_RATE_LIMIT_WAIT_SECONDS:  "Time spent waiting for rate limits to reset, by route and scope.",
+	} {
+		m.counters[name] = &counter{help: help, values: map[string]float64{}}
+	}
+
+	for name, help := range map[string]string{
+		INTERACTION_DURATION: "Time taken to handle interactions, by route and type.",
+		API_REQUEST_DURATION: "Time taken by requests to the API, including their retries, by method and route.",
+	} {
+		m.histograms[name] = &histogram{help: help, series: map[string]*series{}}
+	}
+
+	return m
+}
+
+// ObserveInteraction implements corde.Observer
+func (m *Metrics) ObserveInteraction(e corde.InteractionEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	typ := e.Type.String()
+	if e.NotFound {
+		m.add(INTERACTIONS_NOT_FOUND_TOTAL, 1, "type", typ)
+	}
+
+	l := labels("route", e.Route, "type", typ)
+	m.add(INTERACTIONS_TOTAL, 1, "route", e.Route, "type", typ, "response_type", strconv.Itoa(e.ResponseType))
+	m.observe(INTERACTION_DURATION, l, e.Latency.Seconds())
+	if e.Errored {
+		m.counters[INTERACTION_ERRORS_TOTAL].values[l]++
+	}
+	if e.Panicked {
+		m.counters[INTERACTION_PANICS_TOTAL].values[l]++
+	}
+}
+
+// ObserveRequest implements corde.Observer
+func (m *Metrics) ObserveRequest(e corde.RequestEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := "none"
+	if e.Status != 0 {
+		status = strconv.Itoa(e.Status)
+	}
+
+	l := labels("method", e.Method, "route", e.Route)
+	m.add(API_REQUESTS_TOTAL, 1, "method", e.Method, "route", e.Route, "status", status)
+	m.observe(API_REQUEST_DURATION, l, e.Latency.Seconds())
+	if e.Retries > 0 {
+		m.counters[API_RETRIES_TOTAL].values[l] += float64(e.Retries)
+	}
+}
+
+// ObserveRateLimit implements corde.Observer
+func (m *Metrics) ObserveRateLimit(e corde.RateLimitEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	scope := "route"
+	if e.Global {
+		scope = "global"
+	}
+
+	m.add(API_RATE_LIMIT_WAITS_TOTAL, 1, "route", e.Route, "scope", scope)
+	m.add(API_RATE_LIMIT_WAIT_SECONDS, e.Wait.Seconds(), "route", e.Route, "scope", scope)
+}
+
+// ObserveVerificationFailure implements corde.Observer
+func (m *Metrics) ObserveVerificationFailure() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.add(VERIFICATION_FAILURES_TOTAL, 1)
+}
+
+// add adds v to the counter with the labels, given as name and value pairs
+func (m *Metrics) add(name string, v float64, kv ...string) {
+	m.counters[name].values[labels(kv...)] += v
+}
+
+// observe records v in the histogram with the labels
+func (m *Metrics) observe(name string, labels string, v float64) {
+	h := m.histograms[name]
+	s, ok := h.series[labels]
+	if !ok {
+		s = &series{counts: make([]uint64, len(m.buckets))}
+		h.series[labels] = s
+	}
+
+	s.sum += v
+	s.count++
+	if i := sort.SearchFloat64s(m.buckets, v); i < len(m.buckets) {
+		s.counts[i]++
+	}
+}
+
+// ServeHTTP implements http.Handler, writing the metrics in the Prometheus text format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format, sorted by name and labels
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b := &strings.Builder{}
+	for _, name := range sortedKeys(m.counters) {
+		c := m.counters[name]
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, c.help, name)
+		for _, l := range sortedKeys(c.values) {
+			fmt.Fprintf(b, "%s%s %s\n", name, braces(l), formatFloat(c.values[l]))
+		}
+	}
+
+	for _, name := range sortedKeys(m.histograms) {
+		h := m.histograms[name]
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s histogram\n", name, h.help, name)
+		for _, l := range sortedKeys(h.series) {
+			s := h.series[l]
+
+			var cumulated uint64
+			for i, le := range m.buckets {
+				cumulated += s.counts[i]
+				fmt.Fprintf(b, "%s_bucket%s %d\n", name, braces(join(l, labels("le", formatFloat(le)))), cumulated)
+			}
+			fmt.Fprintf(b, "%s_bucket%s %d\n", name, braces(join(l, labels("le", "+Inf"))), s.count)
+			fmt.Fprintf(b, "%s_sum%s %s\n", name, braces(l), formatFloat(s.sum))
+			fmt.Fprintf(b, "%s_count%s %d\n", name, braces(l), s.count)
+		}
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// labels returns the labels, given as name and value pairs, in the Prometheus text format, without braces
+func labels(kv ...string) string {
+	pairs := make([]string, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		pairs = append(pairs, kv[i]+`="`+escape(kv[i+1])+`"`)
+	}
+	return strings.Join(pairs, ",")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escape escapes the label value
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+func join(a string, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + "," + b
+}
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
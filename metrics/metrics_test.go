@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Karitham/corde"
+	"github.com/matryer/is"
+)
+
+func TestMetrics(t *testing.T) {
+	assert := is.New(t)
+
+	m := New(1, 0.1)
+	m.ObserveInteraction(corde.InteractionEvent{Route: "poll/{id}", Type: corde.ButtonInteraction, ResponseType: 7, Latency: 50 * time.Millisecond})
+	m.ObserveInteraction(corde.InteractionEvent{Route: "poll/{id}", Type: corde.ButtonInteraction, ResponseType: 7, Latency: 500 * time.Millisecond, Errored: true})
+	m.ObserveInteraction(corde.InteractionEvent{Type: corde.SlashCommandInteraction, Latency: 2 * time.Second, NotFound: true})
+	m.ObserveInteraction(corde.InteractionEvent{Route: `say "hi"`, Type: corde.ModalInteraction, Panicked: true})
+	m.ObserveRequest(corde.RequestEvent{Method: "POST", Route: "POST /channels/{id}/messages", Latency: time.Second})
+	m.ObserveRateLimit(corde.RateLimitEvent{Route: "POST /channels/{id}/messages", Wait: 1500 * time.Millisecond})
+	m.ObserveRateLimit(corde.RateLimitEvent{Route: "POST /channels/{id}/messages", Wait: 500 * time.Millisecond})
+	m.ObserveRateLimit(corde.RateLimitEvent{Route: "GET /users/@me", Wait: time.Second, Global: true})
+
+	b := &strings.Builder{}
+	_, err := m.WriteTo(b)
+	assert.NoErr(err)
+	out := b.String()
+
+	for _, line := range []string{
+		"# TYPE corde_interactions_total counter",
+		`corde_interactions_total{route="poll/{id}",type="button",response_type="7"} 2`,
+		`corde_interactions_total{route="",type="slash_command",response_type="0"} 1`,
+		`corde_interactions_not_found_total{type="slash_command"} 1`,
+		`corde_interaction_errors_total{route="poll/{id}",type="button"} 1`,
+		`corde_interaction_panics_total{route="say \"hi\"",type="modal"} 1`,
+		"# TYPE corde_interaction_duration_seconds histogram",
+		`corde_interaction_duration_seconds_bucket{route="poll/{id}",type="button",le="0.1"} 1`,
+		`corde_interaction_duration_seconds_bucket{route="poll/{id}",type="button",le="1"} 2`,
+		`corde_interaction_duration_seconds_bucket{route="poll/{id}",type="button",le="+Inf"} 2`,
+		`corde_interaction_duration_seconds_sum{route="poll/{id}",type="button"} 0.55`,
+		`corde_interaction_duration_seconds_count{route="poll/{id}",type="button"} 2`,
+		`corde_interaction_duration_seconds_bucket{route="",type="slash_command",le="1"} 0`,
+		`corde_api_requests_total{method="POST",route="POST /channels/{id}/messages",status="none"} 1`,
+		`corde_api_rate_limit_waits_total{route="POST /channels/{id}/messages",scope="route"} 2`,
+		`corde_api_rate_limit_wait_seconds_total{route="POST /channels/{id}/messages",scope="route"} 2`,
+		`corde_api_rate_limit_waits_total{route="GET /users/@me",scope="global"} 1`,
+		"# TYPE corde_verification_failures_total counter",
+	} {
+		assert.True(strings.Contains(out, line+"\n"))
+	}
+
+	assert.True(!strings.Contains(out, "corde_api_retries_total{"))
+	assert.True(!strings.Contains(out, "\ncorde_verification_failures_total "))
+}
@@ -0,0 +1,32 @@
+package corde
+
+import "context"
+
+// HandlerFunc is the type-erased form of every handler mounted on the Mux.
+//
+// The interaction it receives is not yet decoded into its concrete data type,
+// but its Route and InnerInteractionType are already resolved.
+type HandlerFunc func(context.Context, ResponseWriter, *Interaction[JsonRaw])
+
+// Middleware wraps a HandlerFunc to run logic before and/or after it
+type Middleware func(HandlerFunc) HandlerFunc
+
+// Use appends middlewares to the Mux.
+//
+// Middlewares are run in the order they are added.
+// Middlewares used on a sub-mux created by Route only wrap the handlers of that sub-mux,
+// and run after the middlewares of their parents.
+func (m *Mux) Use(middlewares ...Middleware) {
+	m.rMu.Lock()
+	defer m.rMu.Unlock()
+
+	m.middlewares = append(m.middlewares, middlewares...)
+}
+
+// chain wraps h with the middlewares, the first middleware being the outermost one
+func chain(middlewares []Middleware, h HandlerFunc) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
@@ -0,0 +1,152 @@
+package corde
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Command attaches the command definition to the mux, and routes its handlers with fn.
+//
+// fn is called with a sub-mux routed on the command name, on which the handlers of the command,
+// its subcommands and its autocompletes are mounted relative to the command.
+// A nil fn only attaches the definition, for handlers mounted elsewhere.
+//
+//	m.Command(corde.NewSlashCommand("todo", "manage your todo list",
+//		corde.NewSubcommand("add", "add a todo", corde.NewStringOption("name", "the todo", true)),
+//		corde.NewSubcommand("list", "list your todos"),
+//	), func(m *corde.Mux) {
+//		m.SlashCommand("add", addTodo)
+//		m.SlashCommand("list", listTodos)
+//	})
+func (m *Mux) Command(c CreateCommander, fn func(m *Mux)) {
+	m.rMu.Lock()
+	m.commands = append(m.commands, c)
+	m.rMu.Unlock()
+
+	if fn != nil {
+		m.Route(commandRoute(c.createCommand().Name), fn)
+	}
+}
+
+// Commands returns the commands attached to the mux, ready to be registered
+// with BulkRegisterCommand or SyncCommands.
+//
+// It fails if a command, subcommand or autocompleted option has no handler mounted,
+// or if a command handler is mounted on a route no attached command leads to.
+func (m *Mux) Commands() ([]CreateCommander, error) {
+	m.rMu.RLock()
+	defer m.rMu.RUnlock()
+
+	var problems []string
+	var leaves []commandLeaf
+	for _, c := range m.commands {
+		leaves = append(leaves, commandLeaves(c.createCommand())...)
+	}
+
+	for _, l := range leaves {
+		if node, _, ok := m.match(l.route, l.typ); !ok || !node.handles(l.typ) {
+			problems = append(problems, fmt.Sprintf("command %q has no handler", l.route))
+		}
+		for _, o := range l.autocomplete {
+			route := path.Join(l.route, o)
+			if node, _, ok := m.match(route, AutocompleteInteraction); !ok || !node.handles(AutocompleteInteraction) {
+				problems = append(problems, fmt.Sprintf("option %q of command %q has no autocomplete handler", o, l.route))
+			}
+		}
+	}
+
+	for route, node := range m.routes.ToMap() {
+		for typ := range node.handlers {
+			if typ != SlashCommandInteraction && typ != UserCommandInteraction && typ != MessageCommandInteraction {
+				continue
+			}
+			if !leadsToLeaf(route, typ, leaves) {
+				problems = append(problems, fmt.Sprintf("handler mounted on %q matches no command", route))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.New("corde: commands don't match the mounted routes: " + strings.Join(problems, "; "))
+	}
+
+	return append([]CreateCommander{}, m.commands...), nil
+}
+
+// commandLeaf is a route a command can be invoked on
+type commandLeaf struct {
+	route        string
+	typ          InnerInteractionType
+	autocomplete []string // the options that can be autocompleted
+}
+
+// commandRoute returns the route of a command name, as computed when routing interactions
+func commandRoute(name string) string {
+	return path.Join(strings.Fields(name)...)
+}
+
+// commandLeaves returns the routes the command can be invoked on,
+// which are the command itself, or each of its subcommands
+func commandLeaves(c CreateCommand) []commandLeaf {
+	route := commandRoute(c.Name)
+	switch c.Type {
+	case COMMAND_USER:
+		return []commandLeaf{{route: route, typ: UserCommandInteraction}}
+	case COMMAND_MESSAGE:
+		return []commandLeaf{{route: route, typ: MessageCommandInteraction}}
+	}
+
+	return optionLeaves(route, c.Options)
+}
+
+// optionLeaves returns the slash command leaves of the route with the options
+func optionLeaves(route string, options []CreateOptioner) []commandLeaf {
+	leaf := commandLeaf{route: route, typ: SlashCommandInteraction}
+	var leaves []commandLeaf
+	for _, opt := range options {
+		o := opt.createOption()
+		switch o.Type {
+		case OPTION_SUB_COMMAND, OPTION_SUB_COMMAND_GROUP:
+			leaves = append(leaves, optionLeaves(path.Join(route, o.Name), o.Options)...)
+		default:
+			if o.Autocomplete {
+				leaf.autocomplete = append(leaf.autocomplete, o.Name)
+			}
+		}
+	}
+
+	if len(leaves) > 0 {
+		return leaves
+	}
+	return []commandLeaf{leaf}
+}
+
+// leadsToLeaf reports wether a handler of the type mounted on the route
+// would handle one of the leaves, either exactly or as a prefix
+func leadsToLeaf(route string, typ InnerInteractionType, leaves []commandLeaf) bool {
+	var p *routePattern
+	if isPattern(route) {
+		p = newRoutePattern(route)
+	}
+
+	for _, l := range leaves {
+		if l.typ != typ {
+			continue
+		}
+		if p != nil {
+			if _, ok := p.match(l.route); ok {
+				return true
+			}
+			continue
+		}
+		if l.route == route || strings.HasPrefix(l.route, route+"/") {
+			return true
+		}
+	}
+
+	return false
+}
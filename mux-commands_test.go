@@ -0,0 +1,54 @@
+package corde_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Karitham/corde"
+	"github.com/matryer/is"
+)
+
+func TestMuxCommands(t *testing.T) {
+	assert := is.New(t)
+	m := corde.NewMux("", 0, "")
+
+	slash := func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.SlashCommandInteractionData]) {}
+	autocomplete := func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.AutocompleteInteractionData]) {}
+
+	m.Command(corde.NewSlashCommand("todo", "manage your todo list",
+		corde.NewSubcommand("add", "add a todo", corde.NewStringOption("name", "the todo", true).CanAutocomplete()),
+		corde.NewSubcommand("list", "list your todos"),
+	), func(m *corde.Mux) {
+		m.SlashCommand("add", slash)
+		m.Autocomplete("add/name", autocomplete)
+		m.SlashCommand("list", slash)
+	})
+	m.Command(corde.NewUserCommand("Show Todos"), nil)
+	m.UserCommand("Show/Todos", func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.UserCommandInteractionData]) {})
+
+	cmds, err := m.Commands()
+	assert.NoErr(err)
+	assert.Equal(len(cmds), 2)
+}
+
+func TestMuxCommandsMismatch(t *testing.T) {
+	assert := is.New(t)
+	m := corde.NewMux("", 0, "")
+
+	slash := func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.SlashCommandInteractionData]) {}
+
+	m.Command(corde.NewSlashCommand("todo", "manage your todo list",
+		corde.NewSubcommand("add", "add a todo", corde.NewStringOption("name", "the todo", true).CanAutocomplete()),
+		corde.NewSubcommand("list", "list your todos"),
+	), func(m *corde.Mux) {
+		m.SlashCommand("list", slash)
+		m.SlashCommand("remove", slash)
+	})
+
+	_, err := m.Commands()
+	assert.True(err != nil)
+	assert.True(strings.Contains(err.Error(), `command "todo/add" has no handler`))
+	assert.True(strings.Contains(err.Error(), `option "name" of command "todo/add" has no autocomplete handler`))
+	assert.True(strings.Contains(err.Error(), `handler mounted on "todo/remove" matches no command`))
+}
@@ -1,12 +1,21 @@
 package corde
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 // ButtonComponent mounts a button route on the mux
 func (m *Mux) ButtonComponent(route string, handler func(context.Context, ResponseWriter, *Interaction[ButtonInteractionData])) {
 	m.Mount(ButtonInteraction, route, handler)
 }
 
+// SelectMenu mounts a select menu route on the mux.
+// It handles every kind of select menu: string, user, role, mentionable and channel selects.
+func (m *Mux) SelectMenu(route string, handler func(context.Context, ResponseWriter, *Interaction[SelectInteractionData])) {
+	m.Mount(SelectMenuInteraction, route, handler)
+}
+
 // Autocomplete mounts an autocomplete route on the mux
 func (m *Mux) Autocomplete(route string, handler func(context.Context, ResponseWriter, *Interaction[AutocompleteInteractionData])) {
 	m.Mount(AutocompleteInteraction, route, handler)
@@ -31,3 +40,69 @@ func (m *Mux) MessageCommand(route string, handler func(context.Context, Respons
 func (m *Mux) Modal(route string, handler func(context.Context, ResponseWriter, *Interaction[ModalInteractionData])) {
 	m.Mount(ModalInteraction, route, handler)
 }
+
+// ButtonComponentE mounts a button route returning an error on the mux
+func (m *Mux) ButtonComponentE(route string, handler func(context.Context, ResponseWriter, *Interaction[ButtonInteractionData]) error) {
+	m.Mount(ButtonInteraction, route, handler)
+}
+
+// SelectMenuE mounts a select menu route returning an error on the mux
+func (m *Mux) SelectMenuE(route string, handler func(context.Context, ResponseWriter, *Interaction[SelectInteractionData]) error) {
+	m.Mount(SelectMenuInteraction, route, handler)
+}
+
+// AutocompleteE mounts an autocomplete route returning an error on the mux
+func (m *Mux) AutocompleteE(route string, handler func(context.Context, ResponseWriter, *Interaction[AutocompleteInteractionData]) error) {
+	m.Mount(AutocompleteInteraction, route, handler)
+}
+
+// SlashCommandE mounts a slash command route returning an error on the mux
+func (m *Mux) SlashCommandE(route string, handler func(context.Context, ResponseWriter, *Interaction[SlashCommandInteractionData]) error) {
+	m.Mount(SlashCommandInteraction, route, handler)
+}
+
+// UserCommandE mounts a user command returning an error on the mux
+func (m *Mux) UserCommandE(route string, handler func(context.Context, ResponseWriter, *Interaction[UserCommandInteractionData]) error) {
+	m.Mount(UserCommandInteraction, route, handler)
+}
+
+// MessageCommandE mounts a message command returning an error on the mux
+func (m *Mux) MessageCommandE(route string, handler func(context.Context, ResponseWriter, *Interaction[MessageCommandInteractionData]) error) {
+	m.Mount(MessageCommandInteraction, route, handler)
+}
+
+// ModalE mounts a modal interaction response returning an error on the mux
+func (m *Mux) ModalE(route string, handler func(context.Context, ResponseWriter, *Interaction[ModalInteractionData]) error) {
+	m.Mount(ModalInteraction, route, handler)
+}
+
+// SlashCommandTyped mounts a slash command route on the mux,
+// decoding the options of the command into args using DecodeOptions before calling the handler.
+//
+// Decoding errors are passed to the OnError handler of the mux as an InvalidOptionsError,
+// and the handler isn't called.
+func SlashCommandTyped[T any](m *Mux, route string, handler func(ctx context.Context, w ResponseWriter, i *Interaction[SlashCommandInteractionData], args T)) {
+	SlashCommandTypedE(m, route, func(ctx context.Context, w ResponseWriter, i *Interaction[SlashCommandInteractionData], args T) error {
+		handler(ctx, w, i, args)
+		return nil
+	})
+}
+
+// SlashCommandTypedE mounts a slash command route returning an error on the mux,
+// decoding the options of the command into args using DecodeOptions before calling the handler.
+//
+// Decoding errors are passed to the OnError handler of the mux as an InvalidOptionsError,
+// and the handler isn't called.
+func SlashCommandTypedE[T any](m *Mux, route string, handler func(ctx context.Context, w ResponseWriter, i *Interaction[SlashCommandInteractionData], args T) error) {
+	m.SlashCommandE(route, func(ctx context.Context, w ResponseWriter, i *Interaction[SlashCommandInteractionData]) error {
+		var args T
+		if err := DecodeOptions(i.Data, &args); err != nil {
+			var optsErr OptionsError
+			if errors.As(err, &optsErr) {
+				return &InvalidOptionsError{Err: optsErr}
+			}
+			return err
+		}
+		return handler(ctx, w, i, args)
+	})
+}
@@ -2,8 +2,9 @@ package corde
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"path"
 	"strings"
@@ -16,16 +17,29 @@ import (
 
 // Mux is a discord gateway muxer, which handles the routing
 type Mux struct {
-	rMu        *sync.RWMutex
-	routes     *radix.Tree[Handlers]
-	PublicKey  string // the hex public key provided by discord
-	BasePath   string // base route path, default is "/"
-	OnNotFound func(context.Context, ResponseWriter, *Interaction[JsonRaw])
-	Client     *http.Client
-	AppID      Snowflake
-	BotToken   string
+	rMu          *sync.RWMutex
+	routes       *radix.Tree[routeNode]
+	patterns     []*routePattern
+	middlewares  []Middleware
+	commands     []CreateCommander
+	PublicKey    string // the hex public key provided by discord
+	BasePath     string // base route path, default is "/"
+	OnNotFound   func(context.Context, ResponseWriter, *Interaction[JsonRaw])
+	OnError      func(context.Context, ResponseWriter, *Interaction[JsonRaw], error)       // called on handler or decoding errors
+	OnPanic      func(context.Context, ResponseWriter, *Interaction[JsonRaw], *PanicError) // called when routing panics
+	OnNoResponse func(context.Context, ResponseWriter, *Interaction[JsonRaw])              // called when nothing was written by the handler, or it returned while auto-deferred
+	Client       *http.Client
+	AppID        Snowflake
+	BotToken     string
+	AutoDefer    time.Duration // defer interactions not responded to after this duration, disabled if 0
+	Logger       *slog.Logger  // logs interactions and requests to the API, slog.Default() if nil
+	Observer     Observer      // notified of interactions and requests to the API, such as to record metrics
 
-	handler http.Handler
+	handler     http.Handler
+	api         rest.Client
+	retry       rest.RetryPolicy
+	limiter     *rest.RateLimiter
+	limiterOnce sync.Once
 }
 
 // Lock the mux, to be able to mount or unmount routes
@@ -42,42 +56,218 @@ func (m *Mux) Unlock() {
 //
 // When you mount a command on the mux, it's prefix based routed,
 // which means you can route to a button like `/list/next/456132153` having mounted `/list/next`
-func NewMux(publicKey string, appID Snowflake, botToken string) *Mux {
+//
+// Routes can also contain named parameters, such as `/list/next/{page}`,
+// which are then retrieved in the handler using RouteParam
+//
+// Handlers run in their own goroutine, and the HTTP response is sent as soon as they write to the ResponseWriter.
+// What they write afterwards is sent as follow-ups or edits of the original response,
+// as such their context is only cancelled with the request until they respond.
+//
+// Requests to the discord API are queued according to its rate limits, shared by every method of the mux,
+// and retried on transient errors according to the RetryPolicy of the mux.
+//
+// Setting AutoDefer makes the mux defer interactions which weren't responded to in time,
+// as discord invalidates interactions not responded to within 3 seconds.
+//
+// The API the mux sends requests to can be configured with options, such as APIRootOpt to target a mock of discord.
+func NewMux(publicKey string, appID Snowflake, botToken string, options ...func(*MuxOpt)) *Mux {
+	opt := &MuxOpt{}
+	for _, option := range options {
+		option(opt)
+	}
+
+	client := opt.client
+	if client == nil {
+		client = &http.Client{
+			Timeout: 10 * time.Second,
+		}
+	}
+
 	m := &Mux{
 		rMu:       &sync.RWMutex{},
-		routes:    radix.New[Handlers](),
+		routes:    radix.New[routeNode](),
 		PublicKey: publicKey,
 		BasePath:  "/",
-		OnNotFound: func(_ context.Context, _ ResponseWriter, i *Interaction[JsonRaw]) {
-			log.Printf("No handler for registered command: %s\n", i.Route)
-		},
-		Client: &http.Client{
-			Timeout: 10 * time.Second,
+		OnNotFound: func(ctx context.Context, _ ResponseWriter, i *Interaction[JsonRaw]) {
+			loggerFrom(ctx).WarnContext(ctx, "no handler for interaction", interactionAttrs(i)...)
 		},
-		AppID:    appID,
-		BotToken: botToken,
+		OnError:      defaultOnError,
+		OnPanic:      defaultOnPanic,
+		OnNoResponse: defaultOnNoResponse,
+		Client:       client,
+		AppID:        appID,
+		BotToken:     botToken,
+		Logger:       opt.logger,
+		Observer:     opt.observer,
+		api:          opt.api,
+		retry:        rest.RetryPolicy(opt.retry),
 	}
 
-	m.handler = rest.Verify(publicKey)(http.HandlerFunc(m.route))
+	m.handler = rest.Verify(publicKey, func() {
+		if m.Observer != nil {
+			m.Observer.ObserveVerificationFailure()
+		}
+	})(http.HandlerFunc(m.route))
 	return m
 }
 
+// client returns the Client of the mux, queuing requests according to the rate limits of discord,
+// and retrying them on transient errors
+func (m *Mux) client() rest.Doer {
+	o := m.restObserver()
+	return m.retry.Client(m.rateLimiter().Client(m.Client, o), m.logger(), o)
+}
+
+// do executes the request, expecting a successful status code, and decodes the response into v if it isn't nil.
+// Error responses are returned as an *APIError.
+func (m *Mux) do(req *http.Request, v any) error {
+	resp, err := m.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(resp)
+	}
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// rateLimiter returns the rate limiter of the mux, creating it on first use
+func (m *Mux) rateLimiter() *rest.RateLimiter {
+	m.limiterOnce.Do(func() {
+		if m.limiter == nil {
+			m.limiter = rest.NewRateLimiter()
+		}
+	})
+	return m.limiter
+}
+
+// MuxOpt is an option for a Mux
+type MuxOpt struct {
+	api      rest.Client
+	retry    RetryPolicy
+	client   *http.Client
+	logger   *slog.Logger
+	observer Observer
+}
+
+// APIRootOpt is an option for setting the root of the API the mux sends requests to, without its version.
+// It defaults to https://discord.com/api
+func APIRootOpt(root string) func(*MuxOpt) {
+	return func(opt *MuxOpt) {
+		opt.api.Root = root
+	}
+}
+
+// APIVersionOpt is an option for setting the version of the API the mux sends requests to
+func APIVersionOpt(version int) func(*MuxOpt) {
+	return func(opt *MuxOpt) {
+		opt.api.Version = version
+	}
+}
+
+// UserAgentOpt is an option for setting the user agent of the requests of the mux.
+// Discord expects it to follow the `DiscordBot ($url, $versionNumber)` format.
+func UserAgentOpt(userAgent string) func(*MuxOpt) {
+	return func(opt *MuxOpt) {
+		opt.api.UserAgent = userAgent
+	}
+}
+
+// HTTPClientOpt is an option for setting the HTTP client of the mux, and thus its transport
+func HTTPClientOpt(c *http.Client) func(*MuxOpt) {
+	return func(opt *MuxOpt) {
+		opt.client = c
+	}
+}
+
+// LoggerOpt is an option for setting the Logger of the mux.
+//
+// Handled interactions and requests to the API are logged at the debug level, the latter along with the dump of their request.
+// Interactions without a handler, without a response, or whose handler failed, and failed requests are logged as warnings and errors.
+func LoggerOpt(l *slog.Logger) func(*MuxOpt) {
+	return func(opt *MuxOpt) {
+		opt.logger = l
+	}
+}
+
+// ObserverOpt is an option for setting the Observer of the mux
+func ObserverOpt(o Observer) func(*MuxOpt) {
+	return func(opt *MuxOpt) {
+		opt.observer = o
+	}
+}
+
+// RetryPolicy is how the mux retries requests failing with transient errors,
+// that is network errors and 500, 502, 503 and 504 responses.
+//
+// Attempts are spaced by an exponential backoff with jitter, or by the Retry-After of the response if set, up to MaxBackoff.
+// Rate limited requests are queued and retried separately, without counting as attempts.
+//
+// Requests which aren't idempotent, such as follow-up messages, are only retried when they couldn't be sent,
+// as discord could have processed them, unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	MaxAttempts        int           // the total number of attempts, 1 disables retries, defaults to 3
+	MinBackoff         time.Duration // the backoff before the first retry, doubled on each attempt, defaults to 100ms
+	MaxBackoff         time.Duration // the maximum backoff between attempts, defaults to 5s
+	RetryNonIdempotent bool          // retry requests which aren't idempotent on any transient error
+}
+
+// RetryOpt is an option for setting the retry policy of the mux
+func RetryOpt(p RetryPolicy) func(*MuxOpt) {
+	return func(opt *MuxOpt) {
+		opt.retry = p
+	}
+}
+
 // Handlers handles incoming requests
 type Handlers map[InnerInteractionType]any
 
+// routeNode is what is stored on each route of the Mux
+type routeNode struct {
+	route       string // the route the node is inserted on
+	handlers    Handlers
+	middlewares []Middleware
+	autoDefer   time.Duration
+}
+
+// handles reports wether the node has a handler for the interaction type
+func (n *routeNode) handles(typ InnerInteractionType) bool {
+	_, ok := n.handlers[typ]
+	return ok
+}
+
 // Route routes common parts along a pattern
+//
+// The middlewares and AutoDefer set on the sub-mux only apply to its routes.
+// Commands attached to the sub-mux are attached to the mux as is, as commands are always top-level.
 func (m *Mux) Route(pattern string, fn func(m *Mux)) {
 	if fn == nil {
 		panic(fmt.Sprintf("corde: attempting to Route() a nil subrouter on %q", pattern))
 	}
 
 	r := NewMux(m.PublicKey, m.AppID, m.BotToken)
+	r.Client, r.api, r.retry, r.limiter, r.Logger, r.Observer = m.Client, m.api, m.retry, m.rateLimiter(), m.Logger, m.Observer
 	fn(r)
 
+	m.rMu.Lock()
+	defer m.rMu.Unlock()
+
 	pattern = strings.TrimLeft(pattern, "/")
-	for route, handler := range r.routes.ToMap() {
-		m.routes.Insert(path.Join(pattern, route), handler)
+	for route, node := range r.routes.ToMap() {
+		// the sub-mux middlewares run before the ones of its own sub-muxes
+		node.middlewares = append(append([]Middleware{}, r.middlewares...), node.middlewares...)
+		if node.autoDefer == 0 {
+			node.autoDefer = r.AutoDefer
+		}
+		m.insert(path.Join(pattern, route), node)
 	}
+	m.commands = append(m.commands, r.commands...)
 }
 
 // Mount is for mounting a Handler on the Mux
@@ -86,9 +276,19 @@ func (m *Mux) Mount(typ InnerInteractionType, route string, handler any) {
 	defer m.rMu.Unlock()
 
 	if r, ok := m.routes.Get(route); ok {
-		(*r)[typ] = handler
+		r.handlers[typ] = handler
 		return
 	}
 
-	m.routes.Insert(route, &Handlers{typ: handler})
+	m.insert(route, &routeNode{handlers: Handlers{typ: handler}})
+}
+
+// insert inserts the node on the route, registering it as a pattern if it has named parameters
+func (m *Mux) insert(route string, node *routeNode) {
+	if isPattern(route) {
+		m.addPattern(route)
+	}
+
+	node.route = route
+	m.routes.Insert(route, node)
 }
@@ -0,0 +1,92 @@
+package corde
+
+import (
+	"context"
+	"time"
+
+	"github.com/Karitham/corde/internal/rest"
+)
+
+// Observer is notified of what a Mux does, such as to record metrics.
+//
+// Its methods are called synchronously, from the goroutines handling interactions and sending requests,
+// and must be safe for concurrent use.
+type Observer interface {
+	// ObserveInteraction is called once an interaction is handled
+	ObserveInteraction(InteractionEvent)
+	// ObserveRequest is called once a request to the API is done, after its retries
+	ObserveRequest(RequestEvent)
+	// ObserveRateLimit is called when a request to the API waits for a rate limit to reset
+	ObserveRateLimit(RateLimitEvent)
+	// ObserveVerificationFailure is called when an inbound request has an invalid signature
+	ObserveVerificationFailure()
+}
+
+// InteractionEvent describes how an interaction was handled
+type InteractionEvent struct {
+	Route        string // the route of the handler, with its named parameters, empty if no handler matched
+	Type         InnerInteractionType
+	ResponseType int           // the type of the HTTP response, 0 if none was written
+	Latency      time.Duration // how long the interaction took to be handled
+	NotFound     bool          // no handler was found for the interaction
+	Errored      bool          // the handler returned an error
+	Panicked     bool          // the handler panicked
+}
+
+// RequestEvent describes a request to the API
+type RequestEvent struct {
+	Method  string
+	Route   string // the templated endpoint of the request, without IDs nor tokens, such as `PATCH /webhooks/{id}/{token}/messages/@original`
+	Status  int    // the HTTP status of the response, 0 if it failed without one
+	Retries int
+	Latency time.Duration // how long the request took, including its retries
+}
+
+// RateLimitEvent describes a wait for a rate limit to reset
+type RateLimitEvent struct {
+	Route  string // the templated endpoint of the request
+	Wait   time.Duration
+	Global bool // the global rate limit was hit, rather than the one of the route
+}
+
+// eventKey is the context key holding the InteractionEvent of the interaction being routed
+type eventKey struct{}
+
+// eventFrom returns the InteractionEvent of the interaction being routed
+func eventFrom(ctx context.Context) *InteractionEvent {
+	if e, ok := ctx.Value(eventKey{}).(*InteractionEvent); ok {
+		return e
+	}
+	return &InteractionEvent{}
+}
+
+// handled logs the interaction, and notifies the Observer of the mux
+func (m *Mux) handled(ctx context.Context, r ResponseWriter, i *Interaction[JsonRaw], start time.Time) {
+	e := eventFrom(ctx)
+	e.Latency = time.Since(start)
+	if rsp, ok := r.(*Responder); ok {
+		e.ResponseType = rsp.responseType()
+	}
+
+	logInteraction(ctx, i, e)
+	if m.Observer != nil {
+		m.Observer.ObserveInteraction(*e)
+	}
+}
+
+// restObserver returns the observer of the requests of the mux, notifying its Observer
+func (m *Mux) restObserver() rest.Observer {
+	o := m.Observer
+	if o == nil {
+		return rest.Observer{}
+	}
+
+	return rest.Observer{
+		OnRequest: func(method string, route string, status int, retries int, latency time.Duration) {
+			o.ObserveRequest(RequestEvent{Method: method, Route: route, Status: status, Retries: retries, Latency: latency})
+		},
+		OnRateLimit: func(route string, wait time.Duration, global bool) {
+			o.ObserveRateLimit(RateLimitEvent{Route: route, Wait: wait, Global: global})
+		},
+	}
+}
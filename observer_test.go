@@ -0,0 +1,79 @@
+package corde_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Karitham/corde"
+	"github.com/Karitham/corde/metrics"
+	"github.com/Karitham/corde/owmock"
+	"github.com/matryer/is"
+)
+
+func TestMuxObserver(t *testing.T) {
+	assert := is.New(t)
+
+	var calls int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(corde.Command{ID: 42, Name: "ping"})
+	}))
+	defer api.Close()
+
+	mt := metrics.New()
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 1234, "",
+		corde.APIRootOpt(api.URL),
+		corde.HTTPClientOpt(api.Client()),
+		corde.RetryOpt(corde.RetryPolicy{MinBackoff: time.Millisecond}),
+		corde.ObserverOpt(mt),
+	)
+	mux.ButtonComponent("{action}", func(_ context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) {
+		w.Respond(corde.NewResp().Content("clicked"))
+	})
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	_, err := owmock.NewWithClient(s.URL, s.Client()).Post(SampleComponent)
+	assert.NoErr(err)
+
+	resp, err := s.Client().Post(s.URL, "application/json", strings.NewReader(SampleComponent))
+	assert.NoErr(err)
+	resp.Body.Close()
+	assert.Equal(resp.StatusCode, http.StatusUnauthorized)
+
+	_, err = mux.GetCommand(context.Background(), 42)
+	assert.NoErr(err)
+	assert.NoErr(mux.EditOriginalInteraction(context.Background(), "secret_token", corde.NewResp().Content("edited")))
+
+	var out string
+	for start := time.Now(); !strings.Contains(out, "corde_interactions_total{") && time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		b := &strings.Builder{}
+		mt.WriteTo(b)
+		out = b.String()
+	}
+
+	assert.True(strings.Contains(out, `corde_interactions_total{route="{action}",type="button",response_type="4"} 1`))
+	assert.True(strings.Contains(out, `corde_interaction_duration_seconds_count{route="{action}",type="button"} 1`))
+	assert.True(strings.Contains(out, "corde_verification_failures_total 1"))
+	assert.True(strings.Contains(out, `corde_api_requests_total{method="GET",route="GET /applications/{id}/commands/{id}",status="200"} 1`))
+	assert.True(strings.Contains(out, `corde_api_retries_total{method="GET",route="GET /applications/{id}/commands/{id}"} 1`))
+	assert.True(strings.Contains(out, `corde_api_requests_total{method="PATCH",route="PATCH /webhooks/{id}/{token}/messages/@original",status="200"} 1`))
+	assert.True(!strings.Contains(out, "secret_token"))
+
+	rec := httptest.NewRecorder()
+	mt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	assert.True(strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4"))
+	assert.True(strings.Contains(string(body), "# TYPE corde_interaction_duration_seconds histogram"))
+}
@@ -17,6 +17,7 @@ const (
 	OPTION_ROLE
 	OPTION_MENTIONABLE
 	OPTION_NUMBER
+	OPTION_ATTACHMENT
 )
 
 type CommandType int
@@ -38,24 +39,45 @@ type Command struct {
 	Options           []Option    `json:"options,omitempty"`
 	DefaultPermission bool        `json:"default_permission,omitempty"`
 	Version           Snowflake   `json:"version,omitempty"`
+
+	DefaultMemberPermissions *Permissions  `json:"default_member_permissions,omitempty"`
+	DMPermission             *bool         `json:"dm_permission,omitempty"`
+	NameLocalizations        Localizations `json:"name_localizations,omitempty"`
+	DescriptionLocalizations Localizations `json:"description_localizations,omitempty"`
 }
 
 // Option is an option for an application Command
 type Option struct {
-	Name        string        `json:"name"`
-	Type        OptionType    `json:"type"`
-	Value       JsonRaw       `json:"value"`
-	Description string        `json:"description,omitempty"`
-	Required    bool          `json:"required,omitempty"`
-	Options     []Option      `json:"options,omitempty"`
-	Choices     []Choice[any] `json:"choices,omitempty"`
-	Focused     bool          `json:"focused,omitempty"`
+	Name         string        `json:"name"`
+	Type         OptionType    `json:"type"`
+	Value        JsonRaw       `json:"value"`
+	Description  string        `json:"description,omitempty"`
+	Required     bool          `json:"required,omitempty"`
+	Options      []Option      `json:"options,omitempty"`
+	Choices      []Choice[any] `json:"choices,omitempty"`
+	ChannelTypes []ChannelType `json:"channel_types,omitempty"`
+	MinValue     *float64      `json:"min_value,omitempty"`
+	MaxValue     *float64      `json:"max_value,omitempty"`
+	MinLength    *int          `json:"min_length,omitempty"`
+	MaxLength    *int          `json:"max_length,omitempty"`
+	Autocomplete bool          `json:"autocomplete,omitempty"`
+	Focused      bool          `json:"focused,omitempty"`
+
+	NameLocalizations        Localizations `json:"name_localizations,omitempty"`
+	DescriptionLocalizations Localizations `json:"description_localizations,omitempty"`
 }
 
 // Choice is an application Command choice
 type Choice[T any] struct {
-	Name  string `json:"name"`
-	Value T      `json:"value"`
+	Name              string        `json:"name"`
+	Value             T             `json:"value"`
+	NameLocalizations Localizations `json:"name_localizations,omitempty"`
+}
+
+// Localize returns the choice, with its name set in the locale
+func (c Choice[T]) Localize(locale string, name string) Choice[T] {
+	c.NameLocalizations = c.NameLocalizations.with(locale, name)
+	return c
 }
 
 // OptionsInteractions is the options for an Interaction
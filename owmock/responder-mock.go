@@ -1,6 +1,7 @@
 package owmock
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/Karitham/corde"
@@ -14,6 +15,15 @@ type ResponseWriterMock struct {
 	ModalHook        func(corde.Modal)
 
 	T *testing.T
+
+	state *rwState // nil if the responses aren't recorded
+}
+
+// rwState records what was written to a ResponseWriterMock, shared by its copies
+type rwState struct {
+	mu       sync.Mutex
+	written  bool
+	deferred bool
 }
 
 // NewRWMock returns a new ResponseWriterMock with the given testing.T
@@ -21,53 +31,122 @@ func NewRWMock(t *testing.T) ResponseWriterMock {
 	return ResponseWriterMock{T: t}
 }
 
+// NewRecordingRWMock returns a new ResponseWriterMock with the given testing.T,
+// recording its responses so that Written and Deferred report them
+func NewRecordingRWMock(t *testing.T) ResponseWriterMock {
+	return ResponseWriterMock{T: t, state: &rwState{}}
+}
+
 // Pong implements ResponseWriter interface
-func (r ResponseWriterMock) Ack() {}
+func (r ResponseWriterMock) Ack() error {
+	r.respond()
+	return nil
+}
 
 // Response implements ResponseWriter interface
-func (r ResponseWriterMock) Respond(i corde.InteractionResponder) {
+func (r ResponseWriterMock) Respond(i corde.InteractionResponder) error {
+	r.respond()
 	if r.RespondHook != nil {
 		r.RespondHook(i)
-		return
+		return nil
 	}
 
 	r.T.Error("unexpected respond hook called")
+	return nil
 }
 
 // DeferedRespond implements ResponseWriter interface
-func (r ResponseWriterMock) DeferedRespond() {}
+func (r ResponseWriterMock) DeferedRespond() error {
+	r.deferResponse()
+	return nil
+}
 
 // Update implements ResponseWriter interface
-func (r ResponseWriterMock) Update(i corde.InteractionResponder) {
+func (r ResponseWriterMock) Update(i corde.InteractionResponder) error {
+	r.respond()
 	if r.UpdateHook != nil {
 		r.UpdateHook(i)
-		return
+		return nil
 	}
 
 	r.T.Error("unexpected update hook called")
+	return nil
 }
 
 // DeferedUpdate implements ResponseWriter interface
-func (r ResponseWriterMock) DeferedUpdate() {}
+func (r ResponseWriterMock) DeferedUpdate() error {
+	r.deferResponse()
+	return nil
+}
 
 // Autocomplete implements ResponseWriter interface
-func (r ResponseWriterMock) Autocomplete(i corde.InteractionResponder) {
+func (r ResponseWriterMock) Autocomplete(i corde.InteractionResponder) error {
+	r.respond()
 	if r.AutocompleteHook != nil {
 		r.AutocompleteHook(i)
-		return
+		return nil
 	}
 
 	r.T.Error("unexpected autocomplete hook called")
+	return nil
 }
 
 // Modal implements ResponseWriter interface
-func (r ResponseWriterMock) Modal(m corde.Modal) {
+func (r ResponseWriterMock) Modal(m corde.Modal) error {
+	r.respond()
 	if r.ModalHook != nil {
 		r.ModalHook(m)
-		return
+		return nil
 	}
 
 	r.T.Error("unexpected modal hook called")
+	return nil
+}
+
+// Written implements ResponseWriter interface, it is always false if the responses aren't recorded
+func (r ResponseWriterMock) Written() bool {
+	if r.state == nil {
+		return false
+	}
+
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	return r.state.written
+}
+
+// Deferred implements ResponseWriter interface, it is always false if the responses aren't recorded
+func (r ResponseWriterMock) Deferred() bool {
+	if r.state == nil {
+		return false
+	}
+
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	return r.state.deferred
+}
+
+// respond records a response, which answers a deferred interaction
+func (r ResponseWriterMock) respond() {
+	if r.state == nil {
+		return
+	}
+
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	r.state.written, r.state.deferred = true, false
+}
+
+// deferResponse records a deferral, if the interaction wasn't answered yet
+func (r ResponseWriterMock) deferResponse() {
+	if r.state == nil {
+		return
+	}
+
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	if !r.state.written {
+		r.state.written, r.state.deferred = true, true
+	}
 }
 
 // type	interaction callback type	the type of response
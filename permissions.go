@@ -0,0 +1,85 @@
+package corde
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Permissions is a permission bit set
+// https://discord.com/developers/docs/topics/permissions#permissions-bitwise-permission-flags
+type Permissions uint64
+
+const (
+	PERMISSION_CREATE_INSTANT_INVITE Permissions = 1 << iota
+	PERMISSION_KICK_MEMBERS
+	PERMISSION_BAN_MEMBERS
+	PERMISSION_ADMINISTRATOR
+	PERMISSION_MANAGE_CHANNELS
+	PERMISSION_MANAGE_GUILD
+	PERMISSION_ADD_REACTIONS
+	PERMISSION_VIEW_AUDIT_LOG
+	PERMISSION_PRIORITY_SPEAKER
+	PERMISSION_STREAM
+	PERMISSION_VIEW_CHANNEL
+	PERMISSION_SEND_MESSAGES
+	PERMISSION_SEND_TTS_MESSAGES
+	PERMISSION_MANAGE_MESSAGES
+	PERMISSION_EMBED_LINKS
+	PERMISSION_ATTACH_FILES
+	PERMISSION_READ_MESSAGE_HISTORY
+	PERMISSION_MENTION_EVERYONE
+	PERMISSION_USE_EXTERNAL_EMOJIS
+	PERMISSION_VIEW_GUILD_INSIGHTS
+	PERMISSION_CONNECT
+	PERMISSION_SPEAK
+	PERMISSION_MUTE_MEMBERS
+	PERMISSION_DEAFEN_MEMBERS
+	PERMISSION_MOVE_MEMBERS
+	PERMISSION_USE_VAD
+	PERMISSION_CHANGE_NICKNAME
+	PERMISSION_MANAGE_NICKNAMES
+	PERMISSION_MANAGE_ROLES
+	PERMISSION_MANAGE_WEBHOOKS
+	PERMISSION_MANAGE_EMOJIS_AND_STICKERS
+	PERMISSION_USE_APPLICATION_COMMANDS
+	PERMISSION_REQUEST_TO_SPEAK
+	PERMISSION_MANAGE_EVENTS
+	PERMISSION_MANAGE_THREADS
+	PERMISSION_CREATE_PUBLIC_THREADS
+	PERMISSION_CREATE_PRIVATE_THREADS
+	PERMISSION_USE_EXTERNAL_STICKERS
+	PERMISSION_SEND_MESSAGES_IN_THREADS
+	PERMISSION_USE_EMBEDDED_ACTIVITIES
+	PERMISSION_MODERATE_MEMBERS
+)
+
+// Has reports wether all the permissions of p are set
+func (ps Permissions) Has(p Permissions) bool {
+	return ps&p == p
+}
+
+// String implements fmt.Stringer
+func (ps Permissions) String() string {
+	return strconv.FormatUint(uint64(ps), 10)
+}
+
+// MarshalJSON implements json.Marshaler
+func (ps Permissions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ps.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (ps *Permissions) UnmarshalJSON(b []byte) error {
+	str, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+
+	i, err := strconv.ParseUint(str, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*ps = Permissions(i)
+	return nil
+}
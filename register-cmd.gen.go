@@ -6,12 +6,16 @@ import "encoding/json"
 
 // StringOption represents a string option
 type StringOption struct {
-	Name         string
-	Description  string
-	Required     bool
-	Choices      []Choice[any]
-	ChannelTypes []ChannelType
-	Autocomplete bool
+	Name                     string
+	Description              string
+	Required                 bool
+	Choices                  []Choice[any]
+	ChannelTypes             []ChannelType
+	Autocomplete             bool
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
+	MinLength                *int
+	MaxLength                *int
 }
 
 // NewStringOption returns a new StringOption
@@ -27,7 +31,7 @@ func NewStringOption(name string, description string, required bool, choices ...
 	for _, choice := range choices {
 		o.Choices = append(
 			o.Choices,
-			Choice[any]{Name: choice.Name, Value: choice.Value},
+			Choice[any]{Name: choice.Name, Value: choice.Value, NameLocalizations: choice.NameLocalizations},
 		)
 	}
 
@@ -37,13 +41,17 @@ func NewStringOption(name string, description string, required bool, choices ...
 // createOption returns the CreateOption of the type
 func (o *StringOption) createOption() CreateOption {
 	return CreateOption{
-		Name:         o.Name,
-		Description:  o.Description,
-		Required:     o.Required,
-		Choices:      o.Choices,
-		ChannelTypes: o.ChannelTypes,
-		Autocomplete: o.Autocomplete,
-		Type:         OPTION_STRING,
+		Name:                     o.Name,
+		Description:              o.Description,
+		Required:                 o.Required,
+		Choices:                  o.Choices,
+		ChannelTypes:             o.ChannelTypes,
+		Autocomplete:             o.Autocomplete,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		MinLength:                o.MinLength,
+		MaxLength:                o.MaxLength,
+		Type:                     OPTION_STRING,
 	}
 }
 
@@ -53,6 +61,13 @@ func (o *StringOption) ChanTypes(typs ...ChannelType) *StringOption {
 	return o
 }
 
+// Localize sets the name and description of the option in the locale
+func (o *StringOption) Localize(locale string, name string, description string) *StringOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
 // MarshalJSON returns the JSON representation of the option
 func (o *StringOption) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.createOption())
@@ -64,14 +79,25 @@ func (o *StringOption) CanAutocomplete() *StringOption {
 	return o
 }
 
+// Length sets the minimum and maximum length of the option
+func (o *StringOption) Length(min int, max int) *StringOption {
+	o.MinLength = &min
+	o.MaxLength = &max
+	return o
+}
+
 // IntOption represents a int option
 type IntOption struct {
-	Name         string
-	Description  string
-	Required     bool
-	Choices      []Choice[any]
-	ChannelTypes []ChannelType
-	Autocomplete bool
+	Name                     string
+	Description              string
+	Required                 bool
+	Choices                  []Choice[any]
+	ChannelTypes             []ChannelType
+	Autocomplete             bool
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
+	MinValue                 *float64
+	MaxValue                 *float64
 }
 
 // NewIntOption returns a new IntOption
@@ -87,7 +113,7 @@ func NewIntOption(name string, description string, required bool, choices ...Cho
 	for _, choice := range choices {
 		o.Choices = append(
 			o.Choices,
-			Choice[any]{Name: choice.Name, Value: choice.Value},
+			Choice[any]{Name: choice.Name, Value: choice.Value, NameLocalizations: choice.NameLocalizations},
 		)
 	}
 
@@ -97,13 +123,17 @@ func NewIntOption(name string, description string, required bool, choices ...Cho
 // createOption returns the CreateOption of the type
 func (o *IntOption) createOption() CreateOption {
 	return CreateOption{
-		Name:         o.Name,
-		Description:  o.Description,
-		Required:     o.Required,
-		Choices:      o.Choices,
-		ChannelTypes: o.ChannelTypes,
-		Autocomplete: o.Autocomplete,
-		Type:         OPTION_INTEGER,
+		Name:                     o.Name,
+		Description:              o.Description,
+		Required:                 o.Required,
+		Choices:                  o.Choices,
+		ChannelTypes:             o.ChannelTypes,
+		Autocomplete:             o.Autocomplete,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		MinValue:                 o.MinValue,
+		MaxValue:                 o.MaxValue,
+		Type:                     OPTION_INTEGER,
 	}
 }
 
@@ -113,6 +143,13 @@ func (o *IntOption) ChanTypes(typs ...ChannelType) *IntOption {
 	return o
 }
 
+// Localize sets the name and description of the option in the locale
+func (o *IntOption) Localize(locale string, name string, description string) *IntOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
 // MarshalJSON returns the JSON representation of the option
 func (o *IntOption) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.createOption())
@@ -124,14 +161,32 @@ func (o *IntOption) CanAutocomplete() *IntOption {
 	return o
 }
 
+// Min sets the minimum value of the option
+func (o *IntOption) Min(min int) *IntOption {
+	v := float64(min)
+	o.MinValue = &v
+	return o
+}
+
+// Max sets the maximum value of the option
+func (o *IntOption) Max(max int) *IntOption {
+	v := float64(max)
+	o.MaxValue = &v
+	return o
+}
+
 // NumberOption represents a float64 option
 type NumberOption struct {
-	Name         string
-	Description  string
-	Required     bool
-	Choices      []Choice[any]
-	ChannelTypes []ChannelType
-	Autocomplete bool
+	Name                     string
+	Description              string
+	Required                 bool
+	Choices                  []Choice[any]
+	ChannelTypes             []ChannelType
+	Autocomplete             bool
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
+	MinValue                 *float64
+	MaxValue                 *float64
 }
 
 // NewNumberOption returns a new NumberOption
@@ -147,7 +202,7 @@ func NewNumberOption(name string, description string, required bool, choices ...
 	for _, choice := range choices {
 		o.Choices = append(
 			o.Choices,
-			Choice[any]{Name: choice.Name, Value: choice.Value},
+			Choice[any]{Name: choice.Name, Value: choice.Value, NameLocalizations: choice.NameLocalizations},
 		)
 	}
 
@@ -157,13 +212,17 @@ func NewNumberOption(name string, description string, required bool, choices ...
 // createOption returns the CreateOption of the type
 func (o *NumberOption) createOption() CreateOption {
 	return CreateOption{
-		Name:         o.Name,
-		Description:  o.Description,
-		Required:     o.Required,
-		Choices:      o.Choices,
-		ChannelTypes: o.ChannelTypes,
-		Autocomplete: o.Autocomplete,
-		Type:         OPTION_NUMBER,
+		Name:                     o.Name,
+		Description:              o.Description,
+		Required:                 o.Required,
+		Choices:                  o.Choices,
+		ChannelTypes:             o.ChannelTypes,
+		Autocomplete:             o.Autocomplete,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		MinValue:                 o.MinValue,
+		MaxValue:                 o.MaxValue,
+		Type:                     OPTION_NUMBER,
 	}
 }
 
@@ -173,6 +232,13 @@ func (o *NumberOption) ChanTypes(typs ...ChannelType) *NumberOption {
 	return o
 }
 
+// Localize sets the name and description of the option in the locale
+func (o *NumberOption) Localize(locale string, name string, description string) *NumberOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
 // MarshalJSON returns the JSON representation of the option
 func (o *NumberOption) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.createOption())
@@ -184,14 +250,30 @@ func (o *NumberOption) CanAutocomplete() *NumberOption {
 	return o
 }
 
+// Min sets the minimum value of the option
+func (o *NumberOption) Min(min float64) *NumberOption {
+	v := float64(min)
+	o.MinValue = &v
+	return o
+}
+
+// Max sets the maximum value of the option
+func (o *NumberOption) Max(max float64) *NumberOption {
+	v := float64(max)
+	o.MaxValue = &v
+	return o
+}
+
 // BoolOption represents a bool option
 type BoolOption struct {
-	Name         string
-	Description  string
-	Required     bool
-	Choices      []Choice[any]
-	ChannelTypes []ChannelType
-	Autocomplete bool
+	Name                     string
+	Description              string
+	Required                 bool
+	Choices                  []Choice[any]
+	ChannelTypes             []ChannelType
+	Autocomplete             bool
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
 }
 
 // NewBoolOption returns a new BoolOption
@@ -207,7 +289,7 @@ func NewBoolOption(name string, description string, required bool, choices ...Ch
 	for _, choice := range choices {
 		o.Choices = append(
 			o.Choices,
-			Choice[any]{Name: choice.Name, Value: choice.Value},
+			Choice[any]{Name: choice.Name, Value: choice.Value, NameLocalizations: choice.NameLocalizations},
 		)
 	}
 
@@ -217,13 +299,15 @@ func NewBoolOption(name string, description string, required bool, choices ...Ch
 // createOption returns the CreateOption of the type
 func (o *BoolOption) createOption() CreateOption {
 	return CreateOption{
-		Name:         o.Name,
-		Description:  o.Description,
-		Required:     o.Required,
-		Choices:      o.Choices,
-		ChannelTypes: o.ChannelTypes,
-		Autocomplete: o.Autocomplete,
-		Type:         OPTION_BOOLEAN,
+		Name:                     o.Name,
+		Description:              o.Description,
+		Required:                 o.Required,
+		Choices:                  o.Choices,
+		ChannelTypes:             o.ChannelTypes,
+		Autocomplete:             o.Autocomplete,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		Type:                     OPTION_BOOLEAN,
 	}
 }
 
@@ -233,6 +317,13 @@ func (o *BoolOption) ChanTypes(typs ...ChannelType) *BoolOption {
 	return o
 }
 
+// Localize sets the name and description of the option in the locale
+func (o *BoolOption) Localize(locale string, name string, description string) *BoolOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
 // MarshalJSON returns the JSON representation of the option
 func (o *BoolOption) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.createOption())
@@ -240,12 +331,14 @@ func (o *BoolOption) MarshalJSON() ([]byte, error) {
 
 // UserOption represents a Snowflake option
 type UserOption struct {
-	Name         string
-	Description  string
-	Required     bool
-	Choices      []Choice[any]
-	ChannelTypes []ChannelType
-	Autocomplete bool
+	Name                     string
+	Description              string
+	Required                 bool
+	Choices                  []Choice[any]
+	ChannelTypes             []ChannelType
+	Autocomplete             bool
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
 }
 
 // NewUserOption returns a new UserOption
@@ -261,7 +354,7 @@ func NewUserOption(name string, description string, required bool, choices ...Ch
 	for _, choice := range choices {
 		o.Choices = append(
 			o.Choices,
-			Choice[any]{Name: choice.Name, Value: choice.Value},
+			Choice[any]{Name: choice.Name, Value: choice.Value, NameLocalizations: choice.NameLocalizations},
 		)
 	}
 
@@ -271,13 +364,15 @@ func NewUserOption(name string, description string, required bool, choices ...Ch
 // createOption returns the CreateOption of the type
 func (o *UserOption) createOption() CreateOption {
 	return CreateOption{
-		Name:         o.Name,
-		Description:  o.Description,
-		Required:     o.Required,
-		Choices:      o.Choices,
-		ChannelTypes: o.ChannelTypes,
-		Autocomplete: o.Autocomplete,
-		Type:         OPTION_USER,
+		Name:                     o.Name,
+		Description:              o.Description,
+		Required:                 o.Required,
+		Choices:                  o.Choices,
+		ChannelTypes:             o.ChannelTypes,
+		Autocomplete:             o.Autocomplete,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		Type:                     OPTION_USER,
 	}
 }
 
@@ -287,6 +382,13 @@ func (o *UserOption) ChanTypes(typs ...ChannelType) *UserOption {
 	return o
 }
 
+// Localize sets the name and description of the option in the locale
+func (o *UserOption) Localize(locale string, name string, description string) *UserOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
 // MarshalJSON returns the JSON representation of the option
 func (o *UserOption) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.createOption())
@@ -294,12 +396,14 @@ func (o *UserOption) MarshalJSON() ([]byte, error) {
 
 // ChannelOption represents a Snowflake option
 type ChannelOption struct {
-	Name         string
-	Description  string
-	Required     bool
-	Choices      []Choice[any]
-	ChannelTypes []ChannelType
-	Autocomplete bool
+	Name                     string
+	Description              string
+	Required                 bool
+	Choices                  []Choice[any]
+	ChannelTypes             []ChannelType
+	Autocomplete             bool
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
 }
 
 // NewChannelOption returns a new ChannelOption
@@ -315,7 +419,7 @@ func NewChannelOption(name string, description string, required bool, choices ..
 	for _, choice := range choices {
 		o.Choices = append(
 			o.Choices,
-			Choice[any]{Name: choice.Name, Value: choice.Value},
+			Choice[any]{Name: choice.Name, Value: choice.Value, NameLocalizations: choice.NameLocalizations},
 		)
 	}
 
@@ -325,13 +429,15 @@ func NewChannelOption(name string, description string, required bool, choices ..
 // createOption returns the CreateOption of the type
 func (o *ChannelOption) createOption() CreateOption {
 	return CreateOption{
-		Name:         o.Name,
-		Description:  o.Description,
-		Required:     o.Required,
-		Choices:      o.Choices,
-		ChannelTypes: o.ChannelTypes,
-		Autocomplete: o.Autocomplete,
-		Type:         OPTION_CHANNEL,
+		Name:                     o.Name,
+		Description:              o.Description,
+		Required:                 o.Required,
+		Choices:                  o.Choices,
+		ChannelTypes:             o.ChannelTypes,
+		Autocomplete:             o.Autocomplete,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		Type:                     OPTION_CHANNEL,
 	}
 }
 
@@ -341,6 +447,13 @@ func (o *ChannelOption) ChanTypes(typs ...ChannelType) *ChannelOption {
 	return o
 }
 
+// Localize sets the name and description of the option in the locale
+func (o *ChannelOption) Localize(locale string, name string, description string) *ChannelOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
 // MarshalJSON returns the JSON representation of the option
 func (o *ChannelOption) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.createOption())
@@ -348,12 +461,14 @@ func (o *ChannelOption) MarshalJSON() ([]byte, error) {
 
 // RoleOption represents a Snowflake option
 type RoleOption struct {
-	Name         string
-	Description  string
-	Required     bool
-	Choices      []Choice[any]
-	ChannelTypes []ChannelType
-	Autocomplete bool
+	Name                     string
+	Description              string
+	Required                 bool
+	Choices                  []Choice[any]
+	ChannelTypes             []ChannelType
+	Autocomplete             bool
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
 }
 
 // NewRoleOption returns a new RoleOption
@@ -369,7 +484,7 @@ func NewRoleOption(name string, description string, required bool, choices ...Ch
 	for _, choice := range choices {
 		o.Choices = append(
 			o.Choices,
-			Choice[any]{Name: choice.Name, Value: choice.Value},
+			Choice[any]{Name: choice.Name, Value: choice.Value, NameLocalizations: choice.NameLocalizations},
 		)
 	}
 
@@ -379,13 +494,15 @@ func NewRoleOption(name string, description string, required bool, choices ...Ch
 // createOption returns the CreateOption of the type
 func (o *RoleOption) createOption() CreateOption {
 	return CreateOption{
-		Name:         o.Name,
-		Description:  o.Description,
-		Required:     o.Required,
-		Choices:      o.Choices,
-		ChannelTypes: o.ChannelTypes,
-		Autocomplete: o.Autocomplete,
-		Type:         OPTION_ROLE,
+		Name:                     o.Name,
+		Description:              o.Description,
+		Required:                 o.Required,
+		Choices:                  o.Choices,
+		ChannelTypes:             o.ChannelTypes,
+		Autocomplete:             o.Autocomplete,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		Type:                     OPTION_ROLE,
 	}
 }
 
@@ -395,6 +512,13 @@ func (o *RoleOption) ChanTypes(typs ...ChannelType) *RoleOption {
 	return o
 }
 
+// Localize sets the name and description of the option in the locale
+func (o *RoleOption) Localize(locale string, name string, description string) *RoleOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
 // MarshalJSON returns the JSON representation of the option
 func (o *RoleOption) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.createOption())
@@ -402,12 +526,14 @@ func (o *RoleOption) MarshalJSON() ([]byte, error) {
 
 // MentionableOption represents a Snowflake option
 type MentionableOption struct {
-	Name         string
-	Description  string
-	Required     bool
-	Choices      []Choice[any]
-	ChannelTypes []ChannelType
-	Autocomplete bool
+	Name                     string
+	Description              string
+	Required                 bool
+	Choices                  []Choice[any]
+	ChannelTypes             []ChannelType
+	Autocomplete             bool
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
 }
 
 // NewMentionableOption returns a new MentionableOption
@@ -423,7 +549,7 @@ func NewMentionableOption(name string, description string, required bool, choice
 	for _, choice := range choices {
 		o.Choices = append(
 			o.Choices,
-			Choice[any]{Name: choice.Name, Value: choice.Value},
+			Choice[any]{Name: choice.Name, Value: choice.Value, NameLocalizations: choice.NameLocalizations},
 		)
 	}
 
@@ -433,13 +559,15 @@ func NewMentionableOption(name string, description string, required bool, choice
 // createOption returns the CreateOption of the type
 func (o *MentionableOption) createOption() CreateOption {
 	return CreateOption{
-		Name:         o.Name,
-		Description:  o.Description,
-		Required:     o.Required,
-		Choices:      o.Choices,
-		ChannelTypes: o.ChannelTypes,
-		Autocomplete: o.Autocomplete,
-		Type:         OPTION_MENTIONABLE,
+		Name:                     o.Name,
+		Description:              o.Description,
+		Required:                 o.Required,
+		Choices:                  o.Choices,
+		ChannelTypes:             o.ChannelTypes,
+		Autocomplete:             o.Autocomplete,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		Type:                     OPTION_MENTIONABLE,
 	}
 }
 
@@ -449,7 +577,79 @@ func (o *MentionableOption) ChanTypes(typs ...ChannelType) *MentionableOption {
 	return o
 }
 
+// Localize sets the name and description of the option in the locale
+func (o *MentionableOption) Localize(locale string, name string, description string) *MentionableOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
 // MarshalJSON returns the JSON representation of the option
 func (o *MentionableOption) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.createOption())
 }
+
+// AttachmentOption represents a Snowflake option
+type AttachmentOption struct {
+	Name                     string
+	Description              string
+	Required                 bool
+	Choices                  []Choice[any]
+	ChannelTypes             []ChannelType
+	Autocomplete             bool
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
+}
+
+// NewAttachmentOption returns a new AttachmentOption
+func NewAttachmentOption(name string, description string, required bool, choices ...Choice[Snowflake]) *AttachmentOption {
+	o := &AttachmentOption{
+		Name:         name,
+		Description:  description,
+		Required:     required,
+		Choices:      []Choice[any]{},
+		ChannelTypes: []ChannelType{},
+	}
+
+	for _, choice := range choices {
+		o.Choices = append(
+			o.Choices,
+			Choice[any]{Name: choice.Name, Value: choice.Value, NameLocalizations: choice.NameLocalizations},
+		)
+	}
+
+	return o
+}
+
+// createOption returns the CreateOption of the type
+func (o *AttachmentOption) createOption() CreateOption {
+	return CreateOption{
+		Name:                     o.Name,
+		Description:              o.Description,
+		Required:                 o.Required,
+		Choices:                  o.Choices,
+		ChannelTypes:             o.ChannelTypes,
+		Autocomplete:             o.Autocomplete,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		Type:                     OPTION_ATTACHMENT,
+	}
+}
+
+// ChanTypes sets the options channel types
+func (o *AttachmentOption) ChanTypes(typs ...ChannelType) *AttachmentOption {
+	o.ChannelTypes = append(o.ChannelTypes, typs...)
+	return o
+}
+
+// Localize sets the name and description of the option in the locale
+func (o *AttachmentOption) Localize(locale string, name string, description string) *AttachmentOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
+// MarshalJSON returns the JSON representation of the option
+func (o *AttachmentOption) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.createOption())
+}
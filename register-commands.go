@@ -23,9 +23,14 @@ type CreateOption struct {
 	Choices      []Choice[any]    `json:"choices,omitempty"`
 	Options      []CreateOptioner `json:"options,omitempty"`
 	ChannelTypes []ChannelType    `json:"channel_types,omitempty"`
-	MinValue     float64          `json:"min_value,omitempty"`
-	MaxValue     float64          `json:"max_value,omitempty"`
+	MinValue     *float64         `json:"min_value,omitempty"`
+	MaxValue     *float64         `json:"max_value,omitempty"`
+	MinLength    *int             `json:"min_length,omitempty"`
+	MaxLength    *int             `json:"max_length,omitempty"`
 	Autocomplete bool             `json:"autocomplete,omitempty"`
+
+	NameLocalizations        Localizations `json:"name_localizations,omitempty"`
+	DescriptionLocalizations Localizations `json:"description_localizations,omitempty"`
 }
 
 func (c CreateOption) createOption() CreateOption {
@@ -34,25 +39,62 @@ func (c CreateOption) createOption() CreateOption {
 
 // CreateCommand is a slash command that can be registered to discord
 type CreateCommand struct {
-	Name                      string           `json:"name,omitempty"`
-	Description               string           `json:"description,omitempty"`
-	Type                      CommandType      `json:"type,omitempty"`
-	Options                   []CreateOptioner `json:"options,omitempty"`
-	DefaultPermissionDisabled bool             `json:"default_permission"`
+	Name        string           `json:"name,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Type        CommandType      `json:"type,omitempty"`
+	Options     []CreateOptioner `json:"options,omitempty"`
+	// DefaultMemberPermissions are the permissions members need to use the command by default,
+	// everyone can use it if nil, and only admins can if set to 0
+	DefaultMemberPermissions *Permissions `json:"default_member_permissions,omitempty"`
+	// DMPermission is wether the command is available in DMs, it is if nil
+	DMPermission *bool `json:"dm_permission,omitempty"`
+
+	NameLocalizations        Localizations `json:"name_localizations,omitempty"`
+	DescriptionLocalizations Localizations `json:"description_localizations,omitempty"`
+	// DefaultPermissionDisabled disables the command by default, default_permission is only sent if it is set.
+	//
+	// Deprecated: use DefaultMemberPermissions instead
+	DefaultPermissionDisabled bool `json:"default_permission"`
 }
 
 // MarshalJSON implements json.Marshaler
 func (c CreateCommand) MarshalJSON() ([]byte, error) {
 	type createCommand CreateCommand
+
+	var defaultPermission *bool
+	if c.DefaultPermissionDisabled {
+		defaultPermission = new(bool)
+	}
+
 	return json.Marshal(struct {
 		createCommand
-		DefaultPermission bool `json:"default_permission"`
+		DefaultPermission *bool `json:"default_permission,omitempty"`
 	}{
 		createCommand:     createCommand(c),
-		DefaultPermission: !c.DefaultPermissionDisabled,
+		DefaultPermission: defaultPermission,
 	})
 }
 
+// RequirePermissions returns the command, only usable by default by members with the permissions
+func (c CreateCommand) RequirePermissions(p Permissions) CreateCommand {
+	c.DefaultMemberPermissions = &p
+	return c
+}
+
+// Localize returns the command, with its name and description set in the locale
+func (c CreateCommand) Localize(locale string, name string, description string) CreateCommand {
+	c.NameLocalizations = c.NameLocalizations.with(locale, name)
+	c.DescriptionLocalizations = c.DescriptionLocalizations.with(locale, description)
+	return c
+}
+
+// GuildOnly returns the command, unavailable in DMs
+func (c CreateCommand) GuildOnly() CreateCommand {
+	dm := false
+	c.DMPermission = &dm
+	return c
+}
+
 // NewSlashCommand returns a new slash command
 func NewSlashCommand(name string, description string, options ...CreateOptioner) CreateCommand {
 	return CreateCommand{
@@ -81,18 +123,25 @@ func NewMessageCommand(name string) CreateCommand {
 
 func (c CreateCommand) createCommand() CreateCommand {
 	return CreateCommand{
-		Name:        c.Name,
-		Description: c.Description,
-		Options:     c.Options,
-		Type:        c.Type,
+		Name:                      c.Name,
+		Description:               c.Description,
+		Options:                   c.Options,
+		Type:                      c.Type,
+		DefaultMemberPermissions:  c.DefaultMemberPermissions,
+		DMPermission:              c.DMPermission,
+		NameLocalizations:         c.NameLocalizations,
+		DescriptionLocalizations:  c.DescriptionLocalizations,
+		DefaultPermissionDisabled: c.DefaultPermissionDisabled,
 	}
 }
 
 // SubcommandOption is an option that is a subcommand
 type SubcommandOption struct {
-	Name        string
-	Description string
-	Options     []CreateOptioner
+	Name                     string
+	Description              string
+	Options                  []CreateOptioner
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
 }
 
 // NewSubcommand returns a new subcommand
@@ -106,22 +155,33 @@ func NewSubcommand(name string, description string, options ...CreateOptioner) *
 
 func (o *SubcommandOption) createOption() CreateOption {
 	return CreateOption{
-		Options:     o.Options,
-		Name:        o.Name,
-		Description: o.Description,
-		Type:        OPTION_SUB_COMMAND,
+		Options:                  o.Options,
+		Name:                     o.Name,
+		Description:              o.Description,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		Type:                     OPTION_SUB_COMMAND,
 	}
 }
 
+// Localize sets the name and description of the option in the locale
+func (o *SubcommandOption) Localize(locale string, name string, description string) *SubcommandOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
 func (o *SubcommandOption) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.createOption())
 }
 
 // SubcommandGroupOption is an option that is a subcommand group
 type SubcommandGroupOption struct {
-	Name        string
-	Description string
-	Options     []CreateOptioner
+	Name                     string
+	Description              string
+	Options                  []CreateOptioner
+	NameLocalizations        Localizations
+	DescriptionLocalizations Localizations
 }
 
 // NewSubcommandGroup returns a new subcommand group
@@ -135,13 +195,22 @@ func NewSubcommandGroup(name string, description string, options ...CreateOption
 
 func (o *SubcommandGroupOption) createOption() CreateOption {
 	return CreateOption{
-		Options:     o.Options,
-		Name:        o.Name,
-		Description: o.Description,
-		Type:        OPTION_SUB_COMMAND_GROUP,
+		Options:                  o.Options,
+		Name:                     o.Name,
+		Description:              o.Description,
+		NameLocalizations:        o.NameLocalizations,
+		DescriptionLocalizations: o.DescriptionLocalizations,
+		Type:                     OPTION_SUB_COMMAND_GROUP,
 	}
 }
 
+// Localize sets the name and description of the option in the locale
+func (o *SubcommandGroupOption) Localize(locale string, name string, description string) *SubcommandGroupOption {
+	o.NameLocalizations = o.NameLocalizations.with(locale, name)
+	o.DescriptionLocalizations = o.DescriptionLocalizations.with(locale, description)
+	return o
+}
+
 func (o *SubcommandGroupOption) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.createOption())
 }
@@ -65,8 +65,57 @@ func (i resolvedInteractionWithOptions) OptionsMessage(k string) (Message, error
 	return m, nil
 }
 
+// OptionsChannel returns the resolved Channel for an Option
+func (i resolvedInteractionWithOptions) OptionsChannel(k string) (Channel, error) {
+	var c Channel
+	s, err := i.Options.Snowflake(k)
+	if err != nil {
+		return c, err
+	}
+	c, ok := i.Resolved.Channels[s]
+	if !ok {
+		return c, fmt.Errorf("no channel found for option %q", k)
+	}
+	return c, nil
+}
+
+// Mentionable is the resolved value of a mentionable option,
+// which is either a User (and Member, in guilds) or a Role
+type Mentionable struct {
+	User   *User
+	Member *Member
+	Role   *Role
+}
+
+// OptionsMentionable returns the resolved User or Role for an Option
+func (i resolvedInteractionWithOptions) OptionsMentionable(k string) (Mentionable, error) {
+	s, err := i.Options.Snowflake(k)
+	if err != nil {
+		return Mentionable{}, err
+	}
+	m, ok := i.Resolved.mentionable(s)
+	if !ok {
+		return m, fmt.Errorf("no user or role found for option %q", k)
+	}
+	return m, nil
+}
+
+// OptionsAttachment returns the resolved Attachment for an Option
+func (i resolvedInteractionWithOptions) OptionsAttachment(k string) (Attachment, error) {
+	var a Attachment
+	s, err := i.Options.Snowflake(k)
+	if err != nil {
+		return a, err
+	}
+	a, ok := i.Resolved.Attachments[s]
+	if !ok {
+		return a, fmt.Errorf("no attachment found for option %q", k)
+	}
+	return a, nil
+}
+
 type ResolvedDataConstraint interface {
-	User | Member | Role | Message
+	User | Member | Role | Message | Channel | Attachment
 }
 
 // ResolvedData is a generic mapping of Snowflakes to resolved data structs
@@ -83,8 +132,26 @@ func (r ResolvedData[T]) First() T {
 }
 
 type Resolved struct {
-	Users    ResolvedData[User]    `json:"users,omitempty"`
-	Members  ResolvedData[Member]  `json:"members,omitempty"`
-	Roles    ResolvedData[Role]    `json:"roles,omitempty"`
-	Messages ResolvedData[Message] `json:"messages,omitempty"`
+	Users       ResolvedData[User]       `json:"users,omitempty"`
+	Members     ResolvedData[Member]     `json:"members,omitempty"`
+	Roles       ResolvedData[Role]       `json:"roles,omitempty"`
+	Messages    ResolvedData[Message]    `json:"messages,omitempty"`
+	Channels    ResolvedData[Channel]    `json:"channels,omitempty"`
+	Attachments ResolvedData[Attachment] `json:"attachments,omitempty"`
+}
+
+// mentionable returns the user or the role resolved for the snowflake
+func (r Resolved) mentionable(s Snowflake) (Mentionable, bool) {
+	if u, ok := r.Users[s]; ok {
+		m := Mentionable{User: &u}
+		if member, ok := r.Members[s]; ok {
+			member.User = u
+			m.Member = &member
+		}
+		return m, true
+	}
+	if role, ok := r.Roles[s]; ok {
+		return Mentionable{Role: &role}, true
+	}
+	return Mentionable{}, false
 }
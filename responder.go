@@ -2,17 +2,49 @@ package corde
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"sync"
+)
+
+// ErrAlreadyResponded is returned when writing a response the interaction can't receive anymore,
+// such as responding with a modal after having responded with a message
+var ErrAlreadyResponded = errors.New("corde: the interaction was already responded to")
+
+// responseState is the state of the response to an interaction
+type responseState int
+
+const (
+	stateUnanswered responseState = iota
+	stateDeferred
+	stateResponded
 )
 
 // Responder loosely maps to the discord gateway response
 // https://discord.com/developers/docs/interactions/receiving-and-responding#responding-to-an-interaction
+//
+// It keeps track of what was written.
+// The first response is written to the HTTP response, which is sent right away.
+// Once deferred, Respond and Update edit the original response.
+// Once responded, Respond sends follow-up messages and Update edits the original response.
 type Responder struct {
-	w http.ResponseWriter
+	mu           sync.Mutex
+	followMu     sync.Mutex // serializes follow-ups, which are sent without holding mu
+	w            http.ResponseWriter
+	m            *Mux
+	ctx          context.Context // the context of the handler, which follow-ups and edits are bound to
+	token        string
+	state        responseState
+	respType     int           // the type of the HTTP response, once written
+	released     bool          // the HTTP response was sent, w can't be written to anymore
+	autoDeferred bool          // the interaction was deferred by the mux
+	firstWrite   chan struct{} // closed on the first write
+	sent         chan struct{} // closed once the HTTP response is sent, follow-ups wait for it
 }
 
 // InteractionResponder returns InteractionRespData
@@ -27,64 +59,193 @@ type intResponse struct {
 	Data *InteractionRespData `json:"data,omitempty"`
 }
 
+// newResponder returns a new Responder for the interaction
+func (m *Mux) newResponder(w http.ResponseWriter, i *Interaction[JsonRaw]) *Responder {
+	return &Responder{
+		w:          w,
+		m:          m,
+		ctx:        context.Background(),
+		token:      i.Token,
+		firstWrite: make(chan struct{}),
+		sent:       make(chan struct{}),
+	}
+}
+
+// Written reports wether the interaction was deferred or responded to
+func (r *Responder) Written() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.state != stateUnanswered
+}
+
+// Deferred reports wether the interaction was deferred and is still waiting for its response
+func (r *Responder) Deferred() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.state == stateDeferred
+}
+
 // Pong responds to pings on the gateway
-func (r *Responder) Ack() {
-	r.w.Header().Set("content-type", "application/json")
-	json.NewEncoder(r.w).Encode(intResponse{Type: 1})
+func (r *Responder) Ack() error {
+	return r.write(1, stateResponded, func() error {
+		return r.encode(intResponse{Type: 1})
+	}, nil)
 }
 
-// Respond responds to the interaction directly
-func (r *Responder) Respond(i InteractionResponder) {
-	r.respond(intResponse{Type: 4, Data: i.InteractionRespData()})
+// Respond responds to the interaction directly,
+// edits the original response if it was deferred,
+// or sends a follow-up message if it was already responded to
+func (r *Responder) Respond(i InteractionResponder) error {
+	return r.write(4, stateResponded, func() error {
+		return r.respond(intResponse{Type: 4, Data: i.InteractionRespData()})
+	}, func(s responseState) (responseState, error) {
+		if s == stateDeferred {
+			return stateResponded, r.m.EditOriginalInteraction(r.ctx, r.token, i)
+		}
+		return stateResponded, r.m.FollowUpInteraction(r.ctx, r.token, i)
+	})
 }
 
 // DeferedRespond responds in defered
-func (r *Responder) DeferedRespond() {
-	r.w.Header().Set("content-type", "application/json")
-	json.NewEncoder(r.w).Encode(intResponse{Type: 5})
+func (r *Responder) DeferedRespond() error {
+	return r.write(5, stateDeferred, func() error {
+		return r.encode(intResponse{Type: 5})
+	}, deferAgain)
 }
 
-// Update updates the target message
-func (r *Responder) Update(i InteractionResponder) {
-	r.respond(intResponse{Type: 7, Data: i.InteractionRespData()})
+// Update updates the target message,
+// or edits the original response if it was already deferred or responded to
+func (r *Responder) Update(i InteractionResponder) error {
+	return r.write(7, stateResponded, func() error {
+		return r.respond(intResponse{Type: 7, Data: i.InteractionRespData()})
+	}, func(responseState) (responseState, error) {
+		return stateResponded, r.m.EditOriginalInteraction(r.ctx, r.token, i)
+	})
 }
 
 // DeferedUpdate updates the target message in defered
-func (r *Responder) DeferedUpdate() {
-	r.w.Header().Set("content-type", "application/json")
-	json.NewEncoder(r.w).Encode(intResponse{Type: 6})
+func (r *Responder) DeferedUpdate() error {
+	return r.write(6, stateDeferred, func() error {
+		return r.encode(intResponse{Type: 6})
+	}, deferAgain)
 }
 
 // Autocomplete responds to the interaction with autocomplete data
-func (r *Responder) Autocomplete(i InteractionResponder) {
-	r.respond(intResponse{Type: 8, Data: i.InteractionRespData()})
+func (r *Responder) Autocomplete(i InteractionResponder) error {
+	return r.write(8, stateResponded, func() error {
+		return r.respond(intResponse{Type: 8, Data: i.InteractionRespData()})
+	}, nil)
 }
 
 // Modal responds to the interaction with modal data
-func (r *Responder) Modal(m Modal) {
+func (r *Responder) Modal(m Modal) error {
+	return r.write(9, stateResponded, func() error {
+		return r.encode(
+			struct {
+				Type int   `json:"type"`
+				Data Modal `json:"data"`
+			}{
+				Type: 9,
+				Data: m,
+			},
+		)
+	}, nil)
+}
+
+// deferAgain is a no-op if the interaction is already deferred
+func deferAgain(s responseState) (responseState, error) {
+	if s == stateDeferred {
+		return s, nil
+	}
+	return s, ErrAlreadyResponded
+}
+
+// write writes the response of the given type using http if the interaction wasn't answered yet,
+// and moves it to the next state.
+// Otherwise, it calls followUp, which returns ErrAlreadyResponded if nil.
+//
+// Follow-ups are sent to the API once the HTTP response is sent, as discord rejects edits of interactions it didn't receive the response of,
+// and without holding the lock of the state, so that Written and Deferred don't wait for them.
+func (r *Responder) write(typ int, next responseState, http func() error, followUp func(responseState) (responseState, error)) error {
+	r.mu.Lock()
+	if r.state == stateUnanswered && !r.released {
+		defer r.mu.Unlock()
+		if err := http(); err != nil {
+			return err
+		}
+
+		r.state = next
+		r.respType = typ
+		close(r.firstWrite)
+		return nil
+	}
+
+	unanswered := r.state == stateUnanswered
+	r.mu.Unlock()
+	if unanswered || followUp == nil {
+		return ErrAlreadyResponded
+	}
+
+	<-r.sent
+	r.followMu.Lock()
+	defer r.followMu.Unlock()
+
+	state, err := followUp(r.currentState())
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.state = state
+	r.mu.Unlock()
+	return nil
+}
+
+// currentState returns the state of the response
+func (r *Responder) currentState() responseState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.state
+}
+
+// responseType returns the type of the HTTP response, or 0 if it wasn't written
+func (r *Responder) responseType() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.respType
+}
+
+// release marks the HTTP response as sent
+func (r *Responder) release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.released {
+		r.released = true
+		close(r.sent)
+	}
+}
+
+func (r *Responder) encode(v any) error {
 	r.w.Header().Set("content-type", "application/json")
-	json.NewEncoder(r.w).Encode(
-		struct {
-			Type int   `json:"type"`
-			Data Modal `json:"data"`
-		}{
-			Type: 9,
-			Data: m,
-		},
-	)
-}
-
-func (r *Responder) respond(i intResponse) {
+	return json.NewEncoder(r.w).Encode(v)
+}
+
+func (r *Responder) respond(i intResponse) error {
 	payloadJSON := &bytes.Buffer{}
 	err := json.NewEncoder(payloadJSON).Encode(i)
 	if err != nil {
-		return
+		return err
 	}
 
 	if len(i.Data.Attachments) < 1 {
 		r.w.Header().Set("content-type", "application/json")
-		payloadJSON.WriteTo(r.w)
-		return
+		_, err := payloadJSON.WriteTo(r.w)
+		return err
 	}
 
 	mw := multipart.NewWriter(r.w)
@@ -101,11 +262,38 @@ func (r *Responder) respond(i intResponse) {
 
 		ff, CFerr := mw.CreateFormFile(fmt.Sprintf("files[%d]", i), f.Filename)
 		if CFerr != nil {
-			return
+			return CFerr
 		}
 
 		if _, CopyErr := io.Copy(ff, f.Body); CopyErr != nil {
+			return CopyErr
+		}
+	}
+
+	return nil
+}
+
+// DefaultNoResponseMessage is the message the default fallback responds with
+// to commands and modals their handler didn't respond to
+var DefaultNoResponseMessage = "This interaction didn't respond."
+
+// defaultOnNoResponse answers the interactions a handler didn't respond to,
+// so that discord doesn't show them as failed.
+// Components are acknowledged without updating their message,
+// and deferred responses are edited with DefaultNoResponseMessage.
+func defaultOnNoResponse(ctx context.Context, w ResponseWriter, i *Interaction[JsonRaw]) {
+	loggerFrom(ctx).WarnContext(ctx, "no response written for interaction", interactionAttrs(i)...)
+
+	switch i.InnerInteractionType {
+	case AutocompleteInteraction:
+		w.Autocomplete(NewResp())
+	case ActionRowInteraction, ButtonInteraction, SelectMenuInteraction, TextInputInteraction:
+		w.DeferedUpdate() // a no-op if already deferred
+	default:
+		if w.Deferred() {
+			w.Respond(NewResp().Content(DefaultNoResponseMessage))
 			return
 		}
+		w.Respond(NewResp().Content(DefaultNoResponseMessage).Ephemeral())
 	}
 }
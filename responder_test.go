@@ -0,0 +1,180 @@
+package corde_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Karitham/corde"
+	"github.com/Karitham/corde/owmock"
+	"github.com/matryer/is"
+)
+
+func TestResponderFollowUp(t *testing.T) {
+	assert := is.New(t)
+
+	served := make(chan struct{})
+	followUps := make(chan string, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-served:
+		case <-time.After(time.Second):
+			t.Error("follow-up sent before the HTTP response")
+		}
+
+		var data corde.InteractionRespData
+		json.NewDecoder(r.Body).Decode(&data)
+		followUps <- r.Method + " " + r.URL.Path + " " + data.Content
+	}))
+	defer api.Close()
+
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 290926444748734465, "", corde.APIRootOpt(api.URL))
+
+	errs := make(chan error, 1)
+	mux.ButtonComponent("click_one", func(_ context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) {
+		assert.True(!w.Written())
+		assert.NoErr(w.Respond(corde.NewResp().Content("Hello World!")))
+		assert.True(w.Written())
+		assert.True(!w.Deferred())
+
+		assert.NoErr(w.Respond(corde.NewResp().Content("Hello again!")))
+		errs <- w.Modal(corde.Modal{Title: "too late"})
+	})
+
+	expect := &owmock.InteractionResponse{
+		Type: 4,
+		Data: corde.InteractionRespData{
+			Content: "Hello World!",
+		},
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+		close(served)
+	}))
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleComponent, expect)
+	assert.NoErr(err)
+
+	select {
+	case f := <-followUps:
+		assert.Equal(f, "POST /v10/webhooks/290926444748734465/unique_interaction_token Hello again!")
+	case <-time.After(time.Second):
+		t.Fatal("expected a follow-up message")
+	}
+	assert.True(errors.Is(<-errs, corde.ErrAlreadyResponded))
+}
+
+func TestResponderDeferThenRespond(t *testing.T) {
+	assert := is.New(t)
+
+	served := make(chan struct{})
+	edits := make(chan string, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-served:
+		case <-time.After(time.Second):
+			t.Error("original response edited before the deferral was sent")
+		}
+
+		var data corde.InteractionRespData
+		json.NewDecoder(r.Body).Decode(&data)
+		edits <- r.Method + " " + r.URL.Path + " " + data.Content
+	}))
+	defer api.Close()
+
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 290926444748734465, "", corde.APIRootOpt(api.URL))
+	mux.ButtonComponent("click_one", func(_ context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) {
+		w.DeferedRespond()
+		assert.NoErr(w.Respond(corde.NewResp().Content("done")))
+	})
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+		close(served)
+	}))
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleComponent, &owmock.InteractionResponse{Type: 5})
+	assert.NoErr(err)
+
+	select {
+	case e := <-edits:
+		assert.Equal(e, "PATCH /v10/webhooks/290926444748734465/unique_interaction_token/messages/@original done")
+	case <-time.After(time.Second):
+		t.Fatal("expected the original response to be edited")
+	}
+}
+
+func TestResponderNoResponse(t *testing.T) {
+	assert := is.New(t)
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 0, "")
+	mux.ButtonComponent("click_one", func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.ButtonInteractionData]) {})
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleComponent, &owmock.InteractionResponse{Type: 6})
+	assert.NoErr(err) // components are acknowledged by default
+}
+
+func TestResponderBackgroundFollowUp(t *testing.T) {
+	assert := is.New(t)
+
+	received, release := make(chan struct{}), make(chan struct{})
+	followUps := make(chan string, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release // a slow API
+		var data corde.InteractionRespData
+		json.NewDecoder(r.Body).Decode(&data)
+		followUps <- data.Content
+	}))
+	defer api.Close()
+
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 290926444748734465, "", corde.APIRootOpt(api.URL))
+
+	writers := make(chan corde.ResponseWriter, 1)
+	mux.ButtonComponent("click_one", func(_ context.Context, w corde.ResponseWriter, _ *corde.Interaction[corde.ButtonInteractionData]) {
+		w.DeferedUpdate()
+		writers <- w
+		go func() {
+			assert.NoErr(w.Update(corde.NewResp().Content("done")))
+		}()
+	})
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleComponent, &owmock.InteractionResponse{Type: 6})
+	assert.NoErr(err)
+
+	w := <-writers
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Fatal("expected the original response to be edited")
+	}
+
+	written := make(chan bool, 1)
+	go func() { written <- w.Written() }()
+	select {
+	case ok := <-written:
+		assert.True(ok) // doesn't wait for the edit
+	case <-time.After(time.Second):
+		t.Fatal("expected Written not to wait for the edit")
+	}
+
+	close(release)
+	select {
+	case content := <-followUps:
+		assert.Equal(content, "done") // the edit outlives the HTTP request
+	case <-time.After(time.Second):
+		t.Fatal("expected the original response to be edited")
+	}
+}
@@ -0,0 +1,169 @@
+package corde
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// routeParamsKey is the context key holding the route params
+type routeParamsKey struct{}
+
+// RouteParam returns the value of the named parameter of the route the interaction was routed on.
+//
+// Mounting a handler on `vote/{pollID}/{choice}` and receiving a button with the custom ID
+// `vote/1234/yes` would give `RouteParam(ctx, "pollID") == "1234"`.
+// It returns an empty string if there is no such parameter.
+func RouteParam(ctx context.Context, name string) string {
+	params, _ := ctx.Value(routeParamsKey{}).(map[string]string)
+	return params[name]
+}
+
+// routePattern is a route containing named parameters, such as `list/next/{page}`
+type routePattern struct {
+	route    string // the route as inserted in the tree
+	segments []string
+}
+
+// isPattern reports wether the route contains named parameters
+func isPattern(route string) bool {
+	return strings.Contains(route, "{")
+}
+
+// paramName returns the name of the parameter if the segment is one
+func paramName(segment string) (string, bool) {
+	if len(segment) > 1 && segment[0] == '{' && segment[len(segment)-1] == '}' {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+// newRoutePattern parses a route pattern, panicking if it is malformed
+func newRoutePattern(route string) *routePattern {
+	p := &routePattern{
+		route:    route,
+		segments: strings.Split(strings.Trim(route, "/"), "/"),
+	}
+
+	seen := map[string]bool{}
+	for _, s := range p.segments {
+		name, ok := paramName(s)
+		switch {
+		case !ok && strings.ContainsAny(s, "{}"):
+			panic(fmt.Sprintf("corde: invalid segment %q in route %q", s, route))
+		case !ok:
+			continue
+		case name == "" || strings.ContainsAny(name, "{}"):
+			panic(fmt.Sprintf("corde: invalid parameter %q in route %q", s, route))
+		case seen[name]:
+			panic(fmt.Sprintf("corde: duplicate parameter %q in route %q", name, route))
+		}
+		seen[name] = true
+	}
+
+	return p
+}
+
+// match returns the params of the route if it matches the pattern
+func (p *routePattern) match(route string) (map[string]string, bool) {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	if len(segments) != len(p.segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, s := range p.segments {
+		if name, ok := paramName(s); ok {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[name] = segments[i]
+			continue
+		}
+
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// conflicts reports wether both patterns match exactly the same routes
+func (p *routePattern) conflicts(o *routePattern) bool {
+	if len(p.segments) != len(o.segments) {
+		return false
+	}
+
+	for i, s := range p.segments {
+		_, isParam := paramName(s)
+		_, oIsParam := paramName(o.segments[i])
+		if isParam != oIsParam || (!isParam && s != o.segments[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// before reports wether p should be tried before o.
+// At the first segment they differ on, static segments take precedence over parameters.
+func (p *routePattern) before(o *routePattern) bool {
+	for i := 0; i < len(p.segments) && i < len(o.segments); i++ {
+		_, isParam := paramName(p.segments[i])
+		_, oIsParam := paramName(o.segments[i])
+		if isParam != oIsParam {
+			return !isParam
+		}
+	}
+
+	return false
+}
+
+// addPattern registers a route pattern on the mux,
+// panicking if it is ambiguous with an already registered one
+func (m *Mux) addPattern(route string) {
+	p := newRoutePattern(route)
+
+	for _, o := range m.patterns {
+		if o.route == p.route {
+			return
+		}
+		if o.conflicts(p) {
+			panic(fmt.Sprintf("corde: route %q is ambiguous with already mounted route %q", p.route, o.route))
+		}
+	}
+
+	for i, o := range m.patterns {
+		if p.before(o) {
+			m.patterns = append(m.patterns[:i], append([]*routePattern{p}, m.patterns[i:]...)...)
+			return
+		}
+	}
+
+	m.patterns = append(m.patterns, p)
+}
+
+// match finds the route node for the route and interaction type.
+//
+// An exact route takes precedence over a route pattern,
+// which itself takes precedence over the longest prefix match.
+func (m *Mux) match(route string, typ InnerInteractionType) (*routeNode, map[string]string, bool) {
+	if node, ok := m.routes.Get(route); ok && !isPattern(route) && node.handles(typ) {
+		return node, nil, true
+	}
+
+	for _, p := range m.patterns {
+		params, ok := p.match(route)
+		if !ok {
+			continue
+		}
+
+		if node, ok := m.routes.Get(p.route); ok && node.handles(typ) {
+			return node, params, true
+		}
+	}
+
+	_, node, ok := m.routes.LongestPrefix(route)
+	return node, nil, ok
+}
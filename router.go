@@ -3,23 +3,28 @@ package corde
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"path"
+	"runtime/debug"
 	"strings"
+	"time"
 )
 
 // ResponseWriter handles responding to interactions
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object-interaction-callback-type
 type ResponseWriter interface {
-	Ack()
-	Respond(InteractionResponder)
-	DeferedRespond()
-	Update(InteractionResponder)
-	DeferedUpdate()
-	Autocomplete(InteractionResponder)
-	Modal(Modal)
+	Ack() error
+	Respond(InteractionResponder) error
+	DeferedRespond() error
+	Update(InteractionResponder) error
+	DeferedUpdate() error
+	Autocomplete(InteractionResponder) error
+	Modal(Modal) error
+	Written() bool  // reports wether the interaction was deferred or responded to
+	Deferred() bool // reports wether the interaction was deferred and is still waiting for its response
 }
 
 // ListenAndServe starts the gateway listening to events
@@ -39,7 +44,7 @@ func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func (m *Mux) route(w http.ResponseWriter, r *http.Request) {
 	i := &Interaction[JsonRaw]{}
 	if err := json.NewDecoder(r.Body).Decode(&i); err != nil {
-		log.Println("Errors unmarshalling json: ", err)
+		m.logger().WarnContext(r.Context(), "decoding interaction", slog.Any("error", err))
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
@@ -75,13 +80,13 @@ func (m *Mux) route(w http.ResponseWriter, r *http.Request) {
 	case INTERACTION_TYPE_MESSAGE_COMPONENT:
 		i.Type = INTERACTION_TYPE_MESSAGE_COMPONENT
 		switch data.ComponentType {
-		case 1:
+		case COMPONENT_ACTION_ROW:
 			i.InnerInteractionType = ActionRowInteraction
-		case 2:
+		case COMPONENT_BUTTON:
 			i.InnerInteractionType = ButtonInteraction
-		case 3:
+		case COMPONENT_STRING_SELECT, COMPONENT_USER_SELECT, COMPONENT_ROLE_SELECT, COMPONENT_MENTIONABLE_SELECT, COMPONENT_CHANNEL_SELECT:
 			i.InnerInteractionType = SelectMenuInteraction
-		case 4:
+		case COMPONENT_TEXT_INPUT:
 			i.InnerInteractionType = TextInputInteraction
 		}
 	case INTERACTION_TYPE_MODAL:
@@ -89,51 +94,138 @@ func (m *Mux) route(w http.ResponseWriter, r *http.Request) {
 		i.InnerInteractionType = ModalInteraction
 	}
 
-	m.routeReq(r.Context(), &Responder{w: w}, i)
+	m.routeReq(r.Context(), m.newResponder(w, i), i)
 }
 
 // routeReq is a recursive implementation to route requests
+//
+// When routing with a Responder, the handler runs in its own goroutine,
+// and routeReq returns as soon as the interaction is deferred or responded to,
+// so that the HTTP response is sent right away.
+// The context of the handler is cancelled with the request until then, and once the handler returns.
 func (m *Mux) routeReq(ctx context.Context, r ResponseWriter, i *Interaction[JsonRaw]) {
-	m.rMu.RLock()
-	defer m.rMu.RUnlock()
 	if i.Type == INTERACTION_TYPE_PING {
 		r.Ack()
 		return
 	}
 
-	var err error
-	if _, handler, ok := m.routes.LongestPrefix(i.Route); ok {
-		switch i.InnerInteractionType {
-		// Component
-		case ButtonInteraction: // works & tested
-			err = routeRequest[ButtonInteractionData](ctx, *handler, i.InnerInteractionType, r, i)
-		case SelectMenuInteraction:
-			err = routeRequest[ModalInteractionData](ctx, *handler, i.InnerInteractionType, r, i)
-		case ActionRowInteraction:
-			err = routeRequest[SelectInteractionData](ctx, *handler, i.InnerInteractionType, r, i)
-		case TextInputInteraction:
-			err = routeRequest[TextInputInteractionData](ctx, *handler, i.InnerInteractionType, r, i)
-
-		// Autocomplete
-		case AutocompleteInteraction:
-			err = routeRequest[AutocompleteInteractionData](ctx, *handler, i.InnerInteractionType, r, i)
-
-		// Slash
-		case SlashCommandInteraction:
-			err = routeRequest[SlashCommandInteractionData](ctx, *handler, i.InnerInteractionType, r, i)
-		case MessageCommandInteraction:
-			err = routeRequest[MessageCommandInteractionData](ctx, *handler, i.InnerInteractionType, r, i)
-		case UserCommandInteraction:
-			err = routeRequest[UserCommandInteractionData](ctx, *handler, i.InnerInteractionType, r, i)
-
-		// Modal
-		case ModalInteraction:
-			err = routeRequest[ModalInteractionData](ctx, *handler, i.InnerInteractionType, r, i)
+	start := time.Now()
+	ctx = context.WithValue(ctx, loggerKey{}, m.logger())
+	ctx = context.WithValue(ctx, eventKey{}, &InteractionEvent{Type: i.InnerInteractionType})
+
+	rsp, ok := r.(*Responder)
+	if !ok {
+		m.rMu.RLock()
+		defer m.rMu.RUnlock()
+
+		m.serve(ctx, r, i)
+		m.handled(ctx, r, i, start)
+		return
+	}
+	defer rsp.release()
+
+	reqCtx := ctx
+	rsp.ctx = context.WithValue(detachedContext{ctx}, responderKey{}, rsp)
+	ctx, cancel := context.WithCancel(rsp.ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer cancel()
+		m.rMu.RLock()
+		defer m.rMu.RUnlock()
+
+		m.serve(ctx, rsp, i)
+		m.handled(ctx, rsp, i, start)
+	}()
+
+	select {
+	case <-done:
+	case <-rsp.firstWrite:
+	case <-reqCtx.Done():
+		cancel() // the request was cancelled before the interaction was answered
+	}
+}
+
+// serve calls the middlewares and the handler, and answers the interaction with OnNoResponse
+// if nothing was written or it is still auto-deferred, recovering panics
+func (m *Mux) serve(ctx context.Context, r ResponseWriter, i *Interaction[JsonRaw]) {
+	defer func() {
+		if p := recover(); p != nil {
+			eventFrom(ctx).Panicked = true
+			m.OnPanic(ctx, r, i, &PanicError{Value: p, Stack: debug.Stack()})
 		}
+	}()
+
+	chain(m.middlewares, m.dispatch)(ctx, r, i)
+
+	if !r.Written() || r.Deferred() && autoDeferred(r) {
+		m.OnNoResponse(ctx, r, i)
 	}
-	if err != nil {
+}
+
+// dispatch finds the route of the interaction and calls its handler, wrapped in the route's middlewares
+func (m *Mux) dispatch(ctx context.Context, r ResponseWriter, i *Interaction[JsonRaw]) {
+	e := eventFrom(ctx)
+	node, params, ok := m.match(i.Route, i.InnerInteractionType)
+	if !ok {
+		e.NotFound = true
 		m.OnNotFound(ctx, r, i)
+		return
+	}
+	e.Route = node.route
+
+	if params != nil {
+		ctx = context.WithValue(ctx, routeParamsKey{}, params)
 	}
+
+	if after := m.nodeAutoDefer(node, i.InnerInteractionType); after > 0 {
+		defer autoDefer(ctx, after, i.InnerInteractionType)()
+	}
+
+	chain(node.middlewares, func(ctx context.Context, r ResponseWriter, i *Interaction[JsonRaw]) {
+		err := node.handlers.route(ctx, r, i)
+		switch {
+		case errors.Is(err, errNoHandler):
+			e.NotFound = true
+			m.OnNotFound(ctx, r, i)
+		case err != nil:
+			e.Errored = true
+			m.OnError(ctx, r, i, err)
+		}
+	})(ctx, r, i)
+}
+
+// route calls the handler matching the inner interaction type
+func (h Handlers) route(ctx context.Context, r ResponseWriter, i *Interaction[JsonRaw]) error {
+	switch i.InnerInteractionType {
+	// Component
+	case ButtonInteraction: // works & tested
+		return routeRequest[ButtonInteractionData](ctx, h, i.InnerInteractionType, r, i)
+	case SelectMenuInteraction:
+		return routeRequest[SelectInteractionData](ctx, h, i.InnerInteractionType, r, i)
+	case ActionRowInteraction: // decoded as select menus, whose data is a superset of that of other components
+		return routeRequest[SelectInteractionData](ctx, h, i.InnerInteractionType, r, i)
+	case TextInputInteraction:
+		return routeRequest[TextInputInteractionData](ctx, h, i.InnerInteractionType, r, i)
+
+	// Autocomplete
+	case AutocompleteInteraction:
+		return routeRequest[AutocompleteInteractionData](ctx, h, i.InnerInteractionType, r, i)
+
+	// Slash
+	case SlashCommandInteraction:
+		return routeRequest[SlashCommandInteractionData](ctx, h, i.InnerInteractionType, r, i)
+	case MessageCommandInteraction:
+		return routeRequest[MessageCommandInteractionData](ctx, h, i.InnerInteractionType, r, i)
+	case UserCommandInteraction:
+		return routeRequest[UserCommandInteractionData](ctx, h, i.InnerInteractionType, r, i)
+
+	// Modal
+	case ModalInteraction:
+		return routeRequest[ModalInteractionData](ctx, h, i.InnerInteractionType, r, i)
+	}
+
+	return fmt.Errorf("%w for unknown interaction type: %d", errNoHandler, i.InnerInteractionType)
 }
 
 // authorize adds the Authorization header to the request
@@ -141,6 +233,9 @@ func (m *Mux) authorize(req *http.Request) {
 	req.Header.Add("Authorization", "Bot "+m.BotToken)
 }
 
+// errNoHandler is returned when no handler is mounted for the interaction type
+var errNoHandler = errors.New("no handler")
+
 // Finds the handler for the route
 func routeRequest[IntReqData InteractionDataConstraint](
 	ctx context.Context,
@@ -149,17 +244,36 @@ func routeRequest[IntReqData InteractionDataConstraint](
 	r ResponseWriter,
 	rawI *Interaction[JsonRaw],
 ) error {
-	if h, ok := routes[it].(func(context.Context, ResponseWriter, *Interaction[IntReqData])); ok {
-		var intValues Interaction[IntReqData]
-		v, _ := json.Marshal(rawI) // Better than mapping by hand, but I hate it
-		if err := json.Unmarshal(v, &intValues); err != nil {
+	switch h := routes[it].(type) {
+	case func(context.Context, ResponseWriter, *Interaction[IntReqData]):
+		i, err := decodeInteraction[IntReqData](rawI)
+		if err != nil {
 			return err
 		}
-		intValues.Route = rawI.Route
 
-		h(ctx, r, &intValues)
+		h(ctx, r, i)
 		return nil
+	case func(context.Context, ResponseWriter, *Interaction[IntReqData]) error:
+		i, err := decodeInteraction[IntReqData](rawI)
+		if err != nil {
+			return err
+		}
+
+		return h(ctx, r, i)
+	}
+
+	return fmt.Errorf("%w for interaction type: %d", errNoHandler, it)
+}
+
+// decodeInteraction decodes the raw interaction into its typed counterpart
+func decodeInteraction[IntReqData InteractionDataConstraint](rawI *Interaction[JsonRaw]) (*Interaction[IntReqData], error) {
+	var intValues Interaction[IntReqData]
+	v, _ := json.Marshal(rawI) // Better than mapping by hand, but I hate it
+	if err := json.Unmarshal(v, &intValues); err != nil {
+		return nil, fmt.Errorf("error decoding interaction: %w", err)
 	}
+	intValues.Route = rawI.Route
+	intValues.InnerInteractionType = rawI.InnerInteractionType
 
-	return fmt.Errorf("no handler for interaction type: %d", it)
+	return &intValues, nil
 }
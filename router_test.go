@@ -1,8 +1,10 @@
 package corde
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -46,3 +48,142 @@ func TestServeHTTP(t *testing.T) {
 	m := NewMux("", Snowflake(0), "")
 	httptest.NewServer(m)
 }
+
+func TestMiddleware(t *testing.T) {
+	assert := is.New(t)
+
+	var calls []string
+	mw := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, w ResponseWriter, i *Interaction[JsonRaw]) {
+				calls = append(calls, name)
+				next(ctx, w, i)
+			}
+		}
+	}
+
+	m := NewMux("", Snowflake(0), "")
+	m.Route("foo", func(m *Mux) {
+		m.Route("bar", func(m *Mux) {
+			m.SlashCommand("baz", func(context.Context, ResponseWriter, *Interaction[SlashCommandInteractionData]) {
+				calls = append(calls, "handler")
+			})
+			m.Use(mw("bar"))
+		})
+		m.Use(mw("foo"))
+	})
+	m.SlashCommand("qux", func(context.Context, ResponseWriter, *Interaction[SlashCommandInteractionData]) {
+		calls = append(calls, "handler")
+	})
+	m.Use(mw("root"))
+
+	i := &Interaction[JsonRaw]{
+		Route:                "foo/bar/baz",
+		InnerInteractionType: SlashCommandInteraction,
+	}
+	m.routeReq(context.Background(), m.newResponder(httptest.NewRecorder(), i), i)
+	assert.Equal(calls, []string{"root", "foo", "bar", "handler"})
+
+	calls = nil
+	i = &Interaction[JsonRaw]{
+		Route:                "qux",
+		InnerInteractionType: SlashCommandInteraction,
+	}
+	m.routeReq(context.Background(), m.newResponder(httptest.NewRecorder(), i), i)
+	assert.Equal(calls, []string{"root", "handler"}) // sub-mux middlewares don't leak to the parent
+}
+
+func TestRouteParams(t *testing.T) {
+	assert := is.New(t)
+
+	var got []string
+	handler := func(name string) func(context.Context, ResponseWriter, *Interaction[ButtonInteractionData]) {
+		return func(ctx context.Context, _ ResponseWriter, _ *Interaction[ButtonInteractionData]) {
+			got = append(got, name, RouteParam(ctx, "pollID"), RouteParam(ctx, "choice"))
+		}
+	}
+
+	m := NewMux("", Snowflake(0), "")
+	m.Route("vote/{pollID}", func(m *Mux) {
+		m.ButtonComponent("{choice}", handler("choice"))
+		m.ButtonComponent("close", handler("close"))
+	})
+	m.ButtonComponent("vote", handler("prefix"))
+
+	tt := []struct {
+		route  string
+		expect []string
+	}{
+		{route: "vote/12/yes", expect: []string{"choice", "12", "yes"}},
+		{route: "vote/12/close", expect: []string{"close", "12", ""}},
+		{route: "vote/12/yes/more", expect: []string{"prefix", "", ""}},
+		{route: "vote", expect: []string{"prefix", "", ""}},
+	}
+
+	for _, tc := range tt {
+		got = nil
+		i := &Interaction[JsonRaw]{
+			Route:                tc.route,
+			InnerInteractionType: ButtonInteraction,
+		}
+		m.routeReq(context.Background(), m.newResponder(httptest.NewRecorder(), i), i)
+		assert.Equal(got, tc.expect)
+	}
+}
+
+func TestRouteParamsAmbiguous(t *testing.T) {
+	assert := is.New(t)
+
+	m := NewMux("", Snowflake(0), "")
+	m.ButtonComponent("list/next/{page}", nil)
+	m.SlashCommand("list/next/{page}", nil) // same route, different type
+
+	defer func() {
+		assert.True(recover() != nil) // mounting an ambiguous route should panic
+	}()
+	m.ButtonComponent("list/next/{offset}", nil)
+}
+
+func TestRouteRequestContext(t *testing.T) {
+	assert := is.New(t)
+
+	errs := make(chan error, 1)
+	m := NewMux("", Snowflake(0), "")
+	m.SlashCommand("ping", func(ctx context.Context, w ResponseWriter, _ *Interaction[SlashCommandInteractionData]) {
+		select {
+		case <-ctx.Done():
+			errs <- ctx.Err()
+		case <-time.After(time.Second):
+			errs <- nil
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	i := &Interaction[JsonRaw]{
+		Route:                "ping",
+		InnerInteractionType: SlashCommandInteraction,
+	}
+	m.routeReq(ctx, m.newResponder(httptest.NewRecorder(), i), i)
+	assert.Equal(<-errs, context.Canceled) // handlers keep the request's cancellation until they respond
+}
+
+func TestRouteActionRow(t *testing.T) {
+	assert := is.New(t)
+
+	var values []string
+	m := NewMux("", Snowflake(0), "")
+	m.Mount(ActionRowInteraction, "pick", func(_ context.Context, w ResponseWriter, i *Interaction[SelectInteractionData]) {
+		values = i.Data.Values
+		w.DeferedUpdate()
+	})
+
+	i := &Interaction[JsonRaw]{
+		Data:                 JsonRaw(`{"custom_id": "pick", "component_type": 1, "values": ["a", "b"]}`),
+		Route:                "pick",
+		InnerInteractionType: ActionRowInteraction,
+	}
+	m.routeReq(context.Background(), m.newResponder(httptest.NewRecorder(), i), i)
+	assert.Equal(values, []string{"a", "b"})
+}
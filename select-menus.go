@@ -0,0 +1,116 @@
+package corde
+
+// StringSelectComponent is a select menu of predefined options
+//
+// https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-menu-structure
+type StringSelectComponent struct {
+	CustomID    string
+	Placeholder string
+	MinValues   int
+	MaxValues   int
+	Disabled    bool
+	Options     []SelectOption
+}
+
+func (s StringSelectComponent) Component() Component {
+	return Component{
+		Type:        COMPONENT_STRING_SELECT,
+		CustomID:    s.CustomID,
+		Placeholder: s.Placeholder,
+		MinValues:   s.MinValues,
+		MaxValues:   s.MaxValues,
+		Disabled:    s.Disabled,
+		Options:     s.Options,
+	}
+}
+
+// UserSelectComponent is a select menu of users
+//
+// https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-menu-structure
+type UserSelectComponent struct {
+	CustomID    string
+	Placeholder string
+	MinValues   int
+	MaxValues   int
+	Disabled    bool
+}
+
+func (s UserSelectComponent) Component() Component {
+	return Component{
+		Type:        COMPONENT_USER_SELECT,
+		CustomID:    s.CustomID,
+		Placeholder: s.Placeholder,
+		MinValues:   s.MinValues,
+		MaxValues:   s.MaxValues,
+		Disabled:    s.Disabled,
+	}
+}
+
+// RoleSelectComponent is a select menu of roles
+//
+// https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-menu-structure
+type RoleSelectComponent struct {
+	CustomID    string
+	Placeholder string
+	MinValues   int
+	MaxValues   int
+	Disabled    bool
+}
+
+func (s RoleSelectComponent) Component() Component {
+	return Component{
+		Type:        COMPONENT_ROLE_SELECT,
+		CustomID:    s.CustomID,
+		Placeholder: s.Placeholder,
+		MinValues:   s.MinValues,
+		MaxValues:   s.MaxValues,
+		Disabled:    s.Disabled,
+	}
+}
+
+// MentionableSelectComponent is a select menu of users and roles
+//
+// https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-menu-structure
+type MentionableSelectComponent struct {
+	CustomID    string
+	Placeholder string
+	MinValues   int
+	MaxValues   int
+	Disabled    bool
+}
+
+func (s MentionableSelectComponent) Component() Component {
+	return Component{
+		Type:        COMPONENT_MENTIONABLE_SELECT,
+		CustomID:    s.CustomID,
+		Placeholder: s.Placeholder,
+		MinValues:   s.MinValues,
+		MaxValues:   s.MaxValues,
+		Disabled:    s.Disabled,
+	}
+}
+
+// ChannelSelectComponent is a select menu of channels,
+// optionally restricted to some channel types
+//
+// https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-menu-structure
+type ChannelSelectComponent struct {
+	CustomID     string
+	Placeholder  string
+	MinValues    int
+	MaxValues    int
+	Disabled     bool
+	ChannelTypes []ChannelType
+}
+
+func (s ChannelSelectComponent) Component() Component {
+	return Component{
+		Type:         COMPONENT_CHANNEL_SELECT,
+		CustomID:     s.CustomID,
+		Placeholder:  s.Placeholder,
+		MinValues:    s.MinValues,
+		MaxValues:    s.MaxValues,
+		Disabled:     s.Disabled,
+		ChannelTypes: s.ChannelTypes,
+	}
+}
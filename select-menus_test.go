@@ -0,0 +1,87 @@
+package corde_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Karitham/corde"
+	"github.com/Karitham/corde/owmock"
+	"github.com/matryer/is"
+)
+
+func TestSelectMenuInteraction(t *testing.T) {
+	assert := is.New(t)
+	pub, _ := owmock.GenerateKeys()
+	mux := corde.NewMux(pub, 0, "")
+
+	mux.SelectMenu("pick_user", func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.SelectInteractionData]) {
+		assert.Equal(i.Data.ComponentType, corde.COMPONENT_USER_SELECT)
+		assert.Equal(len(i.Data.Values), 1)
+
+		u := i.Data.Resolved.Users[corde.SnowflakeFromString(i.Data.Values[0])]
+		w.Respond(corde.NewResp().Contentf("Picked %s", u.Username))
+	})
+
+	expect := &owmock.InteractionResponse{
+		Type: 4,
+		Data: corde.InteractionRespData{
+			Content: "Picked Mason",
+		},
+	}
+
+	s := httptest.NewServer(mux)
+	err := owmock.NewWithClient(s.URL, s.Client()).PostExpect(t, SampleUserSelect, expect)
+	assert.NoErr(err)
+}
+
+func TestSelectMenuComponents(t *testing.T) {
+	assert := is.New(t)
+
+	s := corde.StringSelectComponent{
+		CustomID: "pick_color",
+		Options:  []corde.SelectOption{{Label: "Red", Value: "red"}},
+	}.Component()
+	assert.Equal(s.Type, corde.COMPONENT_SELECT_MENU)
+	assert.Equal(s.Options[0].Value, "red")
+
+	c := corde.ChannelSelectComponent{
+		CustomID:     "pick_channel",
+		ChannelTypes: []corde.ChannelType{corde.CHANNEL_GUILD_TEXT},
+	}.Component()
+	assert.Equal(c.Type, corde.COMPONENT_CHANNEL_SELECT)
+	assert.Equal(c.ChannelTypes, []corde.ChannelType{corde.CHANNEL_GUILD_TEXT})
+}
+
+const SampleUserSelect = `{
+	"version": 1,
+	"type": 3,
+	"token": "unique_interaction_token",
+	"member": {
+		"user": {
+			"username": "Mason",
+			"id": "53908232506183680",
+			"discriminator": "1337"
+		},
+		"roles": [],
+		"permissions": "17179869183"
+	},
+	"id": "846462639134605313",
+	"guild_id": "290926798626357999",
+	"data": {
+		"custom_id": "pick_user",
+		"component_type": 5,
+		"values": ["53908232506183680"],
+		"resolved": {
+			"users": {
+				"53908232506183680": {
+					"username": "Mason",
+					"id": "53908232506183680",
+					"discriminator": "1337"
+				}
+			}
+		}
+	},
+	"channel_id": "345626669114982999",
+	"application_id": "290926444748734465"
+}`
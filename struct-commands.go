@@ -0,0 +1,173 @@
+package corde
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SlashCommandFromStruct returns a slash command whose options are defined by the fields of T,
+// using the same `corde` struct tags as DecodeOptions, so that both never drift apart.
+//
+// Options are typed after their field:
+// strings, integers, floats and booleans map to their option type,
+// User and Member to user options, Role to role options, Channel to channel options,
+// Attachment to attachment options, and Snowflake and Mentionable to mentionable options.
+//
+// The tag accepts the following flags and parameters, separated by commas:
+//
+//	required             the option is required
+//	autocomplete         the option can be autocompleted
+//	choices=a|b|c        the choices of the option, as values or as `name:value` pairs
+//	min=1                the minimum value of the option, or its minimum length for strings
+//	max=10               the maximum value of the option, or its maximum length for strings
+//	channels=0|5         the channel types allowed for channel options
+//
+// The description of an option is read from the `desc` tag, and defaults to its name.
+// Required options are placed before optional ones, as discord requires.
+//
+// It panics if T is not a struct, or if a field or a tag is invalid.
+//
+//	type BanArgs struct {
+//		Member corde.Member `corde:"member,required" desc:"the member to ban"`
+//		Days   int          `corde:"days,min=0,max=7" desc:"days of messages to delete"`
+//	}
+//
+//	corde.SlashCommandFromStruct[BanArgs]("ban", "ban a member")
+func SlashCommandFromStruct[T any](name string, description string) CreateCommand {
+	t := reflect.TypeOf(new(T)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("corde: SlashCommandFromStruct expects a struct, got %s", t))
+	}
+
+	return NewSlashCommand(name, description, structOptions(t)...)
+}
+
+// structOptions returns the options defined by the fields of the struct type
+func structOptions(t reflect.Type) []CreateOptioner {
+	var required, optional []CreateOptioner
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := parseOptionTag(f)
+		if !ok {
+			continue
+		}
+
+		o := structOption(f, tag)
+		if tag.required {
+			required = append(required, o)
+			continue
+		}
+		optional = append(optional, o)
+	}
+
+	return append(required, optional...)
+}
+
+// structOption returns the option defined by the struct field
+func structOption(f reflect.StructField, tag optionTag) CreateOption {
+	desc := f.Tag.Get("desc")
+	if desc == "" {
+		desc = tag.name
+	}
+
+	ft := f.Type
+	if ft.Kind() == reflect.Pointer {
+		ft = ft.Elem()
+	}
+
+	var o CreateOption
+	switch ft {
+	case userType, memberType:
+		o = NewUserOption(tag.name, desc, tag.required).createOption()
+	case roleType:
+		o = NewRoleOption(tag.name, desc, tag.required).createOption()
+	case channelType:
+		o = NewChannelOption(tag.name, desc, tag.required).createOption()
+	case snowflakeType, mentionableType:
+		o = NewMentionableOption(tag.name, desc, tag.required).createOption()
+	case attachmentType:
+		o = NewAttachmentOption(tag.name, desc, tag.required).createOption()
+	default:
+		switch ft.Kind() {
+		case reflect.String:
+			choices := tagChoices(f, tag, func(s string) (string, error) { return s, nil })
+			o = NewStringOption(tag.name, desc, tag.required, choices...).createOption()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			choices := tagChoices(f, tag, strconv.Atoi)
+			o = NewIntOption(tag.name, desc, tag.required, choices...).createOption()
+		case reflect.Float32, reflect.Float64:
+			choices := tagChoices(f, tag, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+			o = NewNumberOption(tag.name, desc, tag.required, choices...).createOption()
+		case reflect.Bool:
+			o = NewBoolOption(tag.name, desc, tag.required).createOption()
+		default:
+			panic(fmt.Sprintf("corde: unsupported option type %s for field %s", f.Type, f.Name))
+		}
+	}
+
+	o.Autocomplete = tag.autocomplete
+	for k, v := range tag.params {
+		switch k {
+		case "choices":
+			// parsed by tagChoices
+		case "min", "max":
+			n := tagFloat(f, k, v)
+			switch {
+			case o.Type == OPTION_STRING && k == "min":
+				l := int(n)
+				o.MinLength = &l
+			case o.Type == OPTION_STRING:
+				l := int(n)
+				o.MaxLength = &l
+			case k == "min":
+				o.MinValue = &n
+			default:
+				o.MaxValue = &n
+			}
+		case "channels":
+			for _, c := range strings.Split(v, "|") {
+				o.ChannelTypes = append(o.ChannelTypes, ChannelType(tagFloat(f, k, c)))
+			}
+		default:
+			panic(fmt.Sprintf("corde: unknown tag parameter %q on field %s", k, f.Name))
+		}
+	}
+
+	return o
+}
+
+// tagChoices parses the choices of the tag, as `value` or `name:value` separated by pipes
+func tagChoices[T any](f reflect.StructField, tag optionTag, parse func(string) (T, error)) []Choice[T] {
+	v, ok := tag.params["choices"]
+	if !ok {
+		return nil
+	}
+
+	var choices []Choice[T]
+	for _, c := range strings.Split(v, "|") {
+		name, value, ok := strings.Cut(c, ":")
+		if !ok {
+			value = name
+		}
+
+		parsed, err := parse(value)
+		if err != nil {
+			panic(fmt.Sprintf("corde: invalid choice %q on field %s: %s", c, f.Name, err))
+		}
+		choices = append(choices, Choice[T]{Name: name, Value: parsed})
+	}
+
+	return choices
+}
+
+// tagFloat parses a numeric tag parameter
+func tagFloat(f reflect.StructField, k string, v string) float64 {
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		panic(fmt.Sprintf("corde: invalid %s %q on field %s: %s", k, v, f.Name, err))
+	}
+	return n
+}
@@ -0,0 +1,104 @@
+package corde_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Karitham/corde"
+	"github.com/matryer/is"
+)
+
+type banArgs struct {
+	Reason  *string       `corde:"reason,autocomplete,max=512" desc:"why the member is banned"`
+	Member  corde.Member  `corde:"member,required" desc:"the member to ban"`
+	Days    int           `corde:"days,min=0,max=7,choices=none:0|week:7"`
+	Log     corde.Channel `corde:"log,channels=0|5"`
+	Silent  bool
+	Ignored string `corde:"-"`
+}
+
+func TestSlashCommandFromStruct(t *testing.T) {
+	assert := is.New(t)
+
+	cmd := corde.SlashCommandFromStruct[banArgs]("ban", "ban a member")
+	b, err := json.Marshal(cmd)
+	assert.NoErr(err)
+
+	var got struct {
+		Name    string `json:"name"`
+		Type    int    `json:"type"`
+		Options []struct {
+			Name         string              `json:"name"`
+			Type         corde.OptionType    `json:"type"`
+			Description  string              `json:"description"`
+			Required     bool                `json:"required"`
+			Autocomplete bool                `json:"autocomplete"`
+			MinValue     *float64            `json:"min_value"`
+			MaxValue     *float64            `json:"max_value"`
+			MaxLength    *int                `json:"max_length"`
+			Choices      []corde.Choice[int] `json:"choices"`
+			ChannelTypes []corde.ChannelType `json:"channel_types"`
+		} `json:"options"`
+	}
+	assert.NoErr(json.Unmarshal(b, &got))
+
+	assert.Equal(got.Name, "ban")
+	assert.Equal(len(got.Options), 5)
+
+	member, reason, days, log, silent := got.Options[0], got.Options[1], got.Options[2], got.Options[3], got.Options[4]
+	assert.Equal(member.Name, "member")
+	assert.Equal(member.Type, corde.OPTION_USER)
+	assert.True(member.Required)
+
+	assert.Equal(reason.Type, corde.OPTION_STRING)
+	assert.Equal(reason.Description, "why the member is banned")
+	assert.True(reason.Autocomplete)
+	assert.Equal(*reason.MaxLength, 512)
+
+	assert.Equal(days.Type, corde.OPTION_INTEGER)
+	assert.Equal(*days.MinValue, 0.0)
+	assert.Equal(*days.MaxValue, 7.0)
+	assert.Equal(days.Choices, []corde.Choice[int]{{Name: "none", Value: 0}, {Name: "week", Value: 7}})
+
+	assert.Equal(log.Type, corde.OPTION_CHANNEL)
+	assert.Equal(log.ChannelTypes, []corde.ChannelType{corde.CHANNEL_GUILD_TEXT, corde.CHANNEL_GUILD_NEWS})
+
+	assert.Equal(silent.Name, "silent")
+	assert.Equal(silent.Type, corde.OPTION_BOOLEAN)
+	assert.Equal(silent.Description, "silent")
+}
+
+func TestSlashCommandFromStructInvalid(t *testing.T) {
+	assert := is.New(t)
+
+	defer func() {
+		assert.True(recover() != nil)
+	}()
+
+	corde.SlashCommandFromStruct[struct {
+		Days int `corde:"days,min=zero"`
+	}]("ban", "ban a member")
+}
+
+func TestOptionConstraints(t *testing.T) {
+	assert := is.New(t)
+
+	cmd := corde.NewSlashCommand("todo", "manage todos",
+		corde.NewStringOption("name", "the todo name", true).Length(1, 100),
+		corde.NewIntOption("priority", "the todo priority", false).Min(0).Max(5),
+		corde.NewAttachmentOption("file", "a file to attach", false),
+	)
+	b, err := json.Marshal(cmd)
+	assert.NoErr(err)
+
+	var got struct {
+		Options []map[string]any `json:"options"`
+	}
+	assert.NoErr(json.Unmarshal(b, &got))
+
+	assert.Equal(got.Options[0]["min_length"], 1.0)
+	assert.Equal(got.Options[0]["max_length"], 100.0)
+	assert.Equal(got.Options[1]["min_value"], 0.0)
+	assert.Equal(got.Options[1]["max_value"], 5.0)
+	assert.Equal(got.Options[2]["type"], float64(corde.OPTION_ATTACHMENT))
+}
@@ -0,0 +1,241 @@
+package corde
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DryRunOpt is an option to compute the changes SyncCommands would make, without applying them
+func DryRunOpt() func(*CommandsOpt) {
+	return func(opt *CommandsOpt) {
+		opt.dryRun = true
+	}
+}
+
+// CommandUpdate is a registered Command that differs from its desired definition
+type CommandUpdate struct {
+	Current Command
+	Desired CreateCommander
+}
+
+// SyncPlan is the set of changes needed to make the registered commands match the desired ones
+type SyncPlan struct {
+	Create []CreateCommander
+	Update []CommandUpdate
+	Delete []Command
+}
+
+// Empty reports wether the registered commands already match the desired ones
+func (p *SyncPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// String returns a human readable summary of the plan, one change per line
+func (p *SyncPlan) String() string {
+	b := &strings.Builder{}
+	for _, c := range p.Create {
+		fmt.Fprintf(b, "+ %s\n", c.createCommand().Name)
+	}
+	for _, u := range p.Update {
+		fmt.Fprintf(b, "~ %s (%s)\n", u.Current.Name, u.Current.ID)
+	}
+	for _, c := range p.Delete {
+		fmt.Fprintf(b, "- %s (%s)\n", c.Name, c.ID)
+	}
+	return b.String()
+}
+
+// SyncCommands makes the registered commands match the desired ones.
+//
+// It fetches the registered commands, diffs them against the desired ones by name and type,
+// and only creates, edits or deletes the commands that differ.
+// Commands are compared structurally, including their options, choices and permissions.
+//
+// With DryRunOpt, the plan is returned without being applied.
+// Otherwise, commands are deleted, then edited, then created, stopping at the first error,
+// so that stale commands don't count towards discord's limit of commands when creating new ones.
+func (m *Mux) SyncCommands(ctx context.Context, desired []CreateCommander, options ...func(*CommandsOpt)) (*SyncPlan, error) {
+	opt := &CommandsOpt{}
+	for _, option := range options {
+		option(opt)
+	}
+
+	current, err := m.GetCommands(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("fetching commands: %w", err)
+	}
+
+	plan, err := diffCommands(current, desired)
+	if err != nil {
+		return nil, err
+	}
+	if opt.dryRun {
+		return plan, nil
+	}
+
+	for _, c := range plan.Delete {
+		if err := m.DeleteCommand(ctx, c.ID, options...); err != nil {
+			return plan, fmt.Errorf("deleting command %q: %w", c.Name, err)
+		}
+	}
+
+	for _, u := range plan.Update {
+		if _, err := m.EditCommand(ctx, u.Current.ID, u.Desired, options...); err != nil {
+			return plan, fmt.Errorf("editing command %q: %w", u.Current.Name, err)
+		}
+	}
+
+	for _, c := range plan.Create {
+		if _, err := m.RegisterCommand(ctx, c, options...); err != nil {
+			return plan, fmt.Errorf("creating command %q: %w", c.createCommand().Name, err)
+		}
+	}
+
+	return plan, nil
+}
+
+// commandKey identifies a command, as commands of different types can share a name
+type commandKey struct {
+	name string
+	typ  CommandType
+}
+
+func keyOf(c Command) commandKey {
+	if c.Type == 0 {
+		c.Type = COMMAND_CHAT_INPUT
+	}
+	return commandKey{name: c.Name, typ: c.Type}
+}
+
+// diffCommands computes the plan to go from the current commands to the desired ones
+func diffCommands(current []Command, desired []CreateCommander) (*SyncPlan, error) {
+	existing := make(map[commandKey]Command, len(current))
+	for _, c := range current {
+		existing[keyOf(c)] = c
+	}
+
+	plan := &SyncPlan{}
+	seen := make(map[commandKey]bool, len(desired))
+	for _, d := range desired {
+		want, err := normalizeCommand(d)
+		if err != nil {
+			return nil, err
+		}
+
+		k := keyOf(want)
+		if seen[k] {
+			return nil, fmt.Errorf("corde: command %q is defined more than once", want.Name)
+		}
+		seen[k] = true
+
+		c, ok := existing[k]
+		switch {
+		case !ok:
+			plan.Create = append(plan.Create, d)
+		case !commandsEqual(c, want):
+			plan.Update = append(plan.Update, CommandUpdate{Current: c, Desired: d})
+		}
+	}
+
+	for _, c := range current {
+		if !seen[keyOf(c)] {
+			plan.Delete = append(plan.Delete, c)
+		}
+	}
+
+	return plan, nil
+}
+
+// normalizeCommand returns the Command discord would register for c
+func normalizeCommand(c CreateCommander) (Command, error) {
+	cmd := Command{DefaultPermission: true} // discord's default, when default_permission isn't sent
+	b, err := json.Marshal(c)
+	if err != nil {
+		return cmd, err
+	}
+	return cmd, json.Unmarshal(b, &cmd)
+}
+
+// commandsEqual reports wether both commands have the same definition
+func commandsEqual(a, b Command) bool {
+	return keyOf(a) == keyOf(b) &&
+		a.Description == b.Description &&
+		a.DefaultPermission == b.DefaultPermission &&
+		ptrEqual(a.DefaultMemberPermissions, b.DefaultMemberPermissions) &&
+		dmPermission(a) == dmPermission(b) &&
+		localizationsEqual(a.NameLocalizations, b.NameLocalizations) &&
+		localizationsEqual(a.DescriptionLocalizations, b.DescriptionLocalizations) &&
+		optionsEqual(a.Options, b.Options)
+}
+
+// localizationsEqual reports wether both localizations hold the same strings, nil being empty
+func localizationsEqual(a, b Localizations) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
+
+// ptrEqual reports wether both values are unset, or are set to the same value
+func ptrEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// dmPermission returns wether the command is available in DMs, which it is by default
+func dmPermission(c Command) bool {
+	return c.DMPermission == nil || *c.DMPermission
+}
+
+// optionsEqual reports wether both option trees have the same definition, in the same order
+func optionsEqual(a, b []Option) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		x, y := a[i], b[i]
+		if x.Name != y.Name ||
+			x.Type != y.Type ||
+			x.Description != y.Description ||
+			x.Required != y.Required ||
+			!ptrEqual(x.MinValue, y.MinValue) ||
+			!ptrEqual(x.MaxValue, y.MaxValue) ||
+			!ptrEqual(x.MinLength, y.MinLength) ||
+			!ptrEqual(x.MaxLength, y.MaxLength) ||
+			x.Autocomplete != y.Autocomplete ||
+			!localizationsEqual(x.NameLocalizations, y.NameLocalizations) ||
+			!localizationsEqual(x.DescriptionLocalizations, y.DescriptionLocalizations) ||
+			len(x.Choices) != len(y.Choices) ||
+			len(x.ChannelTypes) != len(y.ChannelTypes) ||
+			!optionsEqual(x.Options, y.Options) {
+			return false
+		}
+
+		for j := range x.Choices {
+			if x.Choices[j].Name != y.Choices[j].Name ||
+				!reflect.DeepEqual(x.Choices[j].Value, y.Choices[j].Value) ||
+				!localizationsEqual(x.Choices[j].NameLocalizations, y.Choices[j].NameLocalizations) {
+				return false
+			}
+		}
+
+		for j := range x.ChannelTypes {
+			if x.ChannelTypes[j] != y.ChannelTypes[j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,77 @@
+package corde_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Karitham/corde"
+	"github.com/matryer/is"
+)
+
+func TestSyncCommands(t *testing.T) {
+	assert := is.New(t)
+
+	registered := []corde.Command{
+		{ID: 1, Name: "ping", Type: corde.COMMAND_CHAT_INPUT, Description: "ping the bot", DefaultPermission: true},
+		{ID: 2, Name: "ban", Type: corde.COMMAND_CHAT_INPUT, Description: "ban someone", DefaultPermission: true, Options: []corde.Option{
+			{Name: "member", Type: corde.OPTION_USER, Description: "the member to ban", Required: true},
+		}},
+		{ID: 3, Name: "old", Type: corde.COMMAND_CHAT_INPUT, Description: "an old command", DefaultPermission: true},
+	}
+
+	mu := &sync.Mutex{}
+	var calls []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(registered)
+			return
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			io.Copy(w, r.Body) // discord returns the command as registered
+		}
+		calls = append(calls, r.Method+" "+r.URL.Path)
+	}))
+	defer api.Close()
+
+	m := corde.NewMux("", 1234, "", corde.APIRootOpt(api.URL))
+	m.Client = api.Client()
+
+	desired := []corde.CreateCommander{
+		corde.NewSlashCommand("ping", "ping the bot"),
+		corde.NewSlashCommand("ban", "ban someone",
+			corde.NewUserOption("member", "the member to ban", true),
+			corde.NewIntOption("days", "days of messages to delete", false),
+		),
+		corde.NewSlashCommand("kick", "kick someone"),
+	}
+
+	plan, err := m.SyncCommands(context.Background(), desired, corde.DryRunOpt())
+	assert.NoErr(err)
+	assert.Equal(plan.String(), "+ kick\n~ ban (2)\n- old (3)\n")
+	assert.Equal(len(calls), 0)
+
+	_, err = m.SyncCommands(context.Background(), desired)
+	assert.NoErr(err)
+	assert.Equal(calls, []string{
+		"DELETE /v10/applications/1234/commands/3",
+		"PATCH /v10/applications/1234/commands/2",
+		"POST /v10/applications/1234/commands",
+	})
+
+	plan, err = m.SyncCommands(context.Background(), desired[:2], corde.DryRunOpt(), corde.GuildOpt(42))
+	assert.NoErr(err)
+	assert.Equal(plan.String(), "~ ban (2)\n- old (3)\n")
+
+	_, err = m.SyncCommands(context.Background(), append(desired, corde.NewSlashCommand("ping", "again")), corde.DryRunOpt())
+	assert.True(err != nil)
+}
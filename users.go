@@ -1,19 +1,19 @@
 package corde
 
 import (
-	"github.com/Karitham/corde/internal/rest"
+	"context"
 )
 
 // Me returns the current user
-func (m *Mux) Me() (User, error) {
+func (m *Mux) Me(ctx context.Context) (User, error) {
 	var user User
-	_, err := rest.DoJSON(m.Client, rest.Req("/users/@me").Get(m.authorize), &user)
+	err := m.do(m.api.Req(ctx, "/users/@me").Get(m.authorize), &user)
 	return user, err
 }
 
 // GetUser returns a user by id
-func (m *Mux) GetUser(id Snowflake) (User, error) {
+func (m *Mux) GetUser(ctx context.Context, id Snowflake) (User, error) {
 	var user User
-	_, err := rest.DoJSON(m.Client, rest.Req("/users/", id).Get(m.authorize), &user)
+	err := m.do(m.api.Req(ctx, "/users/", id).Get(m.authorize), &user)
 	return user, err
 }